@@ -0,0 +1,122 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package bimibuilder
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+// DefaultSelector is the selector used when a message carries no BIMI-Selector
+// header.
+const DefaultSelector = "default"
+
+type BIMIConfig struct {
+	// Version is the v= tag, "BIMI1" when empty.
+	Version string
+	// Location is the l= tag, the https URL of the SVG logo.
+	Location string
+	// Authority is the a= tag, the https URL of the PEM file holding the
+	// Verified Mark Certificate.
+	Authority string
+	// Declined publishes the declination record, with empty l= and a= tags,
+	// stating the domain has no logo.
+	Declined bool
+	// DMARC is the DMARC policy of the domain. When set it must be enforcing,
+	// as mail receivers only display logos of domains that are.
+	DMARC *dmarcbuilder.DMARCConfig
+}
+
+func BIMIBuilder(value BIMIConfig) (string, error) {
+	if value.Version == "" {
+		value.Version = "BIMI1"
+	}
+	if value.Version != "BIMI1" {
+		return "", fielderror.Errorf("Version", "invalid BIMI version %q, must be BIMI1", value.Version)
+	}
+
+	if value.Declined {
+		if value.Location != "" {
+			return "", fielderror.New("Location", "location must not be set when declining")
+		}
+		if value.Authority != "" {
+			return "", fielderror.New("Authority", "authority must not be set when declining")
+		}
+		return fmt.Sprintf("v=%s; l=; a=", value.Version), nil
+	}
+
+	if value.Location == "" {
+		return "", fielderror.New("Location", "location is required unless declining")
+	}
+	if err := validate.HTTPSURL(value.Location); err != nil {
+		return "", fielderror.Wrap("Location", err)
+	}
+	u, _ := url.Parse(value.Location)
+	if !strings.HasSuffix(strings.ToLower(u.Path), ".svg") {
+		return "", fielderror.Errorf("Location", "invalid logo URL %q, must point to an .svg file", value.Location)
+	}
+
+	record := []string{"v=" + value.Version, "l=" + value.Location}
+
+	if value.Authority != "" {
+		if err := validate.HTTPSURL(value.Authority); err != nil {
+			return "", fielderror.Wrap("Authority", err)
+		}
+		record = append(record, "a="+value.Authority)
+	}
+
+	if value.DMARC != nil {
+		if err := CheckDMARC(*value.DMARC); err != nil {
+			return "", fielderror.Wrap("DMARC", err)
+		}
+	}
+
+	return strings.Join(record, "; "), nil
+}
+
+// CheckDMARC checks that config enforces p=quarantine or p=reject on all
+// messages, as required for BIMI logos to be displayed.
+func CheckDMARC(config dmarcbuilder.DMARCConfig) error {
+	if config.Policy != "quarantine" && config.Policy != "reject" {
+		policy := config.Policy
+		if policy == "" {
+			policy = "none"
+		}
+		return fmt.Errorf("DMARC policy %q is not enforcing, BIMI requires quarantine or reject", policy)
+	}
+	if config.SubdomainPolicy == "none" {
+		return errors.New(`DMARC subdomain policy "none" is not enforcing, BIMI requires quarantine or reject`)
+	}
+	if (config.Percent != 0 || config.PercentSet) && config.Percent != 100 {
+		return fmt.Errorf("DMARC policy applies to %d%% of messages, BIMI requires pct=100", config.Percent)
+	}
+	if config.Testing {
+		return errors.New("DMARC policy is in testing mode, BIMI requires it to be enforced")
+	}
+	return nil
+}
+
+// RecordName returns the name of the BIMI record of selector, or of
+// DefaultSelector when empty, followed by domain unless it is empty.
+func RecordName(selector string, domain string) (string, error) {
+	selector = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(selector), "."))
+	if selector == "" {
+		selector = DefaultSelector
+	}
+	if !validate.Hostname(selector) {
+		return "", fielderror.Errorf("Selector", "invalid BIMI selector %q, must be dot separated labels of letters, digits and hyphens", selector)
+	}
+
+	name, err := validate.RecordName(selector+"._bimi", domain)
+	if err != nil {
+		return "", fielderror.Wrap("Domain", err)
+	}
+	return name, nil
+}
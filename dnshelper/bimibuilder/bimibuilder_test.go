@@ -0,0 +1,211 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package bimibuilder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/bimibuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+func TestBIMIBuilder(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      bimibuilder.BIMIConfig
+		want      string
+		wantField string
+	}{
+		{
+			name: "Logo",
+			args: bimibuilder.BIMIConfig{Location: "https://example.com/bimi/logo.svg"},
+			want: "v=BIMI1; l=https://example.com/bimi/logo.svg",
+		},
+		{
+			name: "Logo And Certificate",
+			args: bimibuilder.BIMIConfig{
+				Version:   "BIMI1",
+				Location:  "https://example.com/bimi/logo.SVG",
+				Authority: "https://example.com/bimi/vmc.pem",
+			},
+			want: "v=BIMI1; l=https://example.com/bimi/logo.SVG; a=https://example.com/bimi/vmc.pem",
+		},
+		{
+			name: "Declined",
+			args: bimibuilder.BIMIConfig{Declined: true},
+			want: "v=BIMI1; l=; a=",
+		},
+		{
+			name: "Enforcing DMARC",
+			args: bimibuilder.BIMIConfig{
+				Location: "https://example.com/logo.svg",
+				DMARC:    &dmarcbuilder.DMARCConfig{Policy: "reject", Percent: 100},
+			},
+			want: "v=BIMI1; l=https://example.com/logo.svg",
+		},
+		{
+			name: "Declined Ignores DMARC",
+			args: bimibuilder.BIMIConfig{Declined: true, DMARC: &dmarcbuilder.DMARCConfig{Policy: "none"}},
+			want: "v=BIMI1; l=; a=",
+		},
+		{
+			name:      "Invalid Version",
+			args:      bimibuilder.BIMIConfig{Version: "BIMI2", Location: "https://example.com/logo.svg"},
+			wantField: "Version",
+		},
+		{
+			name:      "Missing Location",
+			args:      bimibuilder.BIMIConfig{Authority: "https://example.com/vmc.pem"},
+			wantField: "Location",
+		},
+		{
+			name:      "HTTP Location",
+			args:      bimibuilder.BIMIConfig{Location: "http://example.com/logo.svg"},
+			wantField: "Location",
+		},
+		{
+			name:      "Not SVG",
+			args:      bimibuilder.BIMIConfig{Location: "https://example.com/logo.png"},
+			wantField: "Location",
+		},
+		{
+			name:      "Unencoded Semicolon",
+			args:      bimibuilder.BIMIConfig{Location: "https://example.com/a;b.svg"},
+			wantField: "Location",
+		},
+		{
+			name:      "HTTP Authority",
+			args:      bimibuilder.BIMIConfig{Location: "https://example.com/logo.svg", Authority: "http://example.com/vmc.pem"},
+			wantField: "Authority",
+		},
+		{
+			name:      "Declined With Location",
+			args:      bimibuilder.BIMIConfig{Declined: true, Location: "https://example.com/logo.svg"},
+			wantField: "Location",
+		},
+		{
+			name: "Monitoring DMARC",
+			args: bimibuilder.BIMIConfig{
+				Location: "https://example.com/logo.svg",
+				DMARC:    &dmarcbuilder.DMARCConfig{Policy: "none"},
+			},
+			wantField: "DMARC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bimibuilder.BIMIBuilder(tt.args)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("BIMIBuilder() error = %v, want field %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BIMIBuilder() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BIMIBuilder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDMARC(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string
+		wantErr string
+	}{
+		{
+			name:   "Reject",
+			record: "v=DMARC1; p=reject",
+		},
+		{
+			name:   "Quarantine",
+			record: "v=DMARC1; p=quarantine; pct=100; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:    "None",
+			record:  "v=DMARC1; p=none",
+			wantErr: `DMARC policy "none" is not enforcing, BIMI requires quarantine or reject`,
+		},
+		{
+			name:    "Subdomain None",
+			record:  "v=DMARC1; p=reject; sp=none",
+			wantErr: `DMARC subdomain policy "none" is not enforcing, BIMI requires quarantine or reject`,
+		},
+		{
+			name:    "Partial",
+			record:  "v=DMARC1; p=quarantine; pct=50",
+			wantErr: "DMARC policy applies to 50% of messages, BIMI requires pct=100",
+		},
+		{
+			name:    "Zero Percent",
+			record:  "v=DMARC1; p=reject; pct=0",
+			wantErr: "DMARC policy applies to 0% of messages, BIMI requires pct=100",
+		},
+		{
+			name:    "Testing",
+			record:  "v=DMARC1; p=reject; t=y",
+			wantErr: "DMARC policy is in testing mode, BIMI requires it to be enforced",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := dmarcbuilder.DmarcParse(tt.record)
+			if err != nil {
+				t.Fatalf("DmarcParse() error = %v", err)
+			}
+			err = bimibuilder.CheckDMARC(config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckDMARC() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CheckDMARC() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	tests := []struct {
+		selector  string
+		domain    string
+		want      string
+		wantField string
+	}{
+		{want: "default._bimi"},
+		{selector: "Brand", domain: "Example.com.", want: "brand._bimi.example.com"},
+		{selector: "bad_selector", wantField: "Selector"},
+		{domain: "example..com", wantField: "Domain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector+"/"+tt.domain, func(t *testing.T) {
+			got, err := bimibuilder.RecordName(tt.selector, tt.domain)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("RecordName() error = %v, want field %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RecordName() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RecordName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@
 package caabuilder
 
 import (
-	"errors"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
 )
 
 type CAARecord struct {
@@ -24,57 +27,209 @@ func CAA(tag string, value string, flag int) CAARecord {
 	}
 }
 
+// String returns the record in presentation format, e.g.
+// `0 issue "letsencrypt.org"`, escaping quotes and backslashes in the value.
+func (r CAARecord) String() string {
+	value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(r.Value)
+	return strconv.Itoa(r.Flag) + " " + r.Tag + " " + `"` + value + `"`
+}
+
 type CAAConfig struct {
-	Iodef             string
-	IodefCritical     bool
-	Issue             []string
+	// Iodef lists the mailto:, http: or https: URLs CAs report invalid
+	// certificate requests to (RFC 8659 section 4.4).
+	Iodef         []string
+	IodefCritical bool
+	// IodefMailto prefixes bare email addresses in Iodef with mailto:.
+	IodefMailto bool
+	// Issue and Issuewild list the CAs allowed to issue certificates and
+	// wildcard certificates. An Issuer without Domain forbids issuance and
+	// must be the only entry; ParseIssuers builds them from strings.
+	Issue             []Issuer
 	IssueCritical     bool
-	Issuewild         []string
+	Issuewild         []Issuer
 	IssuewildCritical bool
+	// Issuemail lists the CAs allowed to issue S/MIME certificates (RFC 9495).
+	Issuemail         []Issuer
+	IssuemailCritical bool
+	// Issuevmc lists the CAs allowed to issue BIMI Verified Mark Certificates.
+	Issuevmc         []Issuer
+	IssuevmcCritical bool
+	// Contactemail and Contactphone are the domain contacts CAs may use for
+	// domain validation (CA/Browser Forum Baseline Requirements 3.2.2.4.13
+	// and 3.2.2.4.16).
+	Contactemail []string
+	Contactphone []string
+	// StrictIssuers rejects issuer domain names missing from the issuer
+	// registry, which are otherwise accepted for private and unlisted CAs.
+	StrictIssuers bool
+	// Dedup drops repeated records and Sort orders records by tag, then
+	// value, so that the output does not depend on the input order.
+	Dedup bool
+	Sort  bool
+	// EntryCritical sets the critical flag of single iodef, issue,
+	// issuewild, issuemail or issuevmc entries, keyed by tag with one flag
+	// per entry. Tags without an entry use their *Critical field.
+	EntryCritical map[string][]bool
+	// Extra lists records with tags the builder does not know about, such as
+	// experimental CA properties. They are emitted after all other records.
+	Extra []CAARecord
 }
 
 func CAABuilder(value CAAConfig) ([]CAARecord, error) {
-	if len(value.Issue) == 1 && value.Issue[0] == "none" {
-		value.Issue = []string{";"}
+	var err error
+	if value.Iodef, err = iodefValues(value.Iodef, value.IodefMailto); err != nil {
+		return nil, err
+	}
+	issue, err := issuerValues("Issue", value.Issue, value.StrictIssuers)
+	if err != nil {
+		return nil, err
+	}
+	issuewild, err := issuerValues("Issuewild", value.Issuewild, value.StrictIssuers)
+	if err != nil {
+		return nil, err
+	}
+	issuemail, err := issuerValues("Issuemail", value.Issuemail, value.StrictIssuers)
+	if err != nil {
+		return nil, err
 	}
-	if len(value.Issuewild) == 1 && value.Issuewild[0] == "none" {
-		value.Issuewild = []string{";"}
+	issuevmc, err := issuerValues("Issuevmc", value.Issuevmc, value.StrictIssuers)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(value.Issue) == 0 && len(value.Issuewild) == 0 {
-		return nil, errors.New("CAABuilder requires at least one entry in issue or issuewild")
+	for i, email := range value.Contactemail {
+		if err := validateContactEmail(email); err != nil {
+			return nil, fielderror.Wrap(fielderror.Index("Contactemail", i), err)
+		}
+	}
+	for i, phone := range value.Contactphone {
+		if err := validateContactPhone(phone); err != nil {
+			return nil, fielderror.Wrap(fielderror.Index("Contactphone", i), err)
+		}
 	}
 
-	r := []CAARecord{}
+	if len(issue) == 0 && len(issuewild) == 0 && len(issuemail) == 0 && len(issuevmc) == 0 {
+		return nil, fielderror.New("Issue", "CAABuilder requires at least one entry in issue, issuewild, issuemail or issuevmc")
+	}
 
-	if value.Iodef != "" {
-		if value.IodefCritical {
-			r = append(r, CAA("iodef", value.Iodef, caaCritical))
-		} else {
-			r = append(r, CAA("iodef", value.Iodef, 0))
+	critical := map[string]bool{
+		"iodef":     value.IodefCritical,
+		"issue":     value.IssueCritical,
+		"issuewild": value.IssuewildCritical,
+		"issuemail": value.IssuemailCritical,
+		"issuevmc":  value.IssuevmcCritical,
+	}
+	entries := map[string]int{
+		"iodef":     len(value.Iodef),
+		"issue":     len(issue),
+		"issuewild": len(issuewild),
+		"issuemail": len(issuemail),
+		"issuevmc":  len(issuevmc),
+	}
+	if err := validateEntryCritical(value.EntryCritical, entries); err != nil {
+		return nil, err
+	}
+	flag := func(tag string, i int) int {
+		if flags, ok := value.EntryCritical[tag]; ok {
+			return criticalFlag(flags[i])
 		}
+		return criticalFlag(critical[tag])
 	}
 
-	if len(value.Issue) > 0 {
-		flag := 0
-		if value.IssueCritical {
-			flag = caaCritical
-		}
-		for _, issue := range value.Issue {
-			r = append(r, CAA("issue", issue, flag))
+	extra, err := extraRecords(value.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	r := []CAARecord{}
+
+	for i, iodef := range value.Iodef {
+		r = append(r, CAA("iodef", iodef, flag("iodef", i)))
+	}
+
+	for i, issue := range issue {
+		r = append(r, CAA("issue", issue, flag("issue", i)))
+	}
+
+	for i, issuewild := range issuewild {
+		r = append(r, CAA("issuewild", issuewild, flag("issuewild", i)))
+	}
+
+	for i, issuemail := range issuemail {
+		r = append(r, CAA("issuemail", issuemail, flag("issuemail", i)))
+	}
+
+	for i, issuevmc := range issuevmc {
+		r = append(r, CAA("issuevmc", issuevmc, flag("issuevmc", i)))
+	}
+
+	for _, email := range value.Contactemail {
+		r = append(r, CAA("contactemail", email, 0))
+	}
+
+	for _, phone := range value.Contactphone {
+		r = append(r, CAA("contactphone", phone, 0))
+	}
+
+	r = append(r, extra...)
+
+	if value.Dedup {
+		r = dedup(r)
+	}
+	if value.Sort {
+		r = sortRecords(r)
+	}
+
+	return r, nil
+}
+
+// dedup removes repeated records, keeping the first occurrence. Values are
+// compared in canonical form.
+func dedup(records []CAARecord) []CAARecord {
+	seen := map[CAARecord]bool{}
+	r := make([]CAARecord, 0, len(records))
+	for _, record := range records {
+		if seen[record] {
+			continue
 		}
+		seen[record] = true
+		r = append(r, record)
 	}
+	return r
+}
+
+// sortRecords sorts records by tag, then value, matching DNS providers which
+// sort record sets server-side.
+func sortRecords(records []CAARecord) []CAARecord {
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Tag != records[j].Tag {
+			return records[i].Tag < records[j].Tag
+		}
+		return records[i].Value < records[j].Value
+	})
+	return records
+}
 
-	if len(value.Issuewild) > 0 {
-		flag := 0
-		if value.IssuewildCritical {
-			flag = caaCritical
+// issuerValues validates the issuer entries of field and returns them as
+// property values, with CA aliases replaced and other domains lowercased.
+func issuerValues(field string, issuers []Issuer, strict bool) ([]string, error) {
+	r := make([]string, 0, len(issuers))
+	for i, issuer := range issuers {
+		if issuer.Domain == "" && len(issuers) > 1 {
+			return nil, fielderror.New(fielderror.Index(field, i), "an issuer without domain name forbids issuance and must be the only entry")
 		}
-		for _, issuewild := range value.Issuewild {
-			r = append(r, CAA("issuewild", issuewild, flag))
+		if err := issuer.Validate(); err != nil {
+			return nil, fielderror.Wrap(fielderror.Index(field, i), err)
 		}
+		if _, domain, ok := LookupIssuer(issuer.Domain); ok {
+			issuer.Domain = domain
+		} else if issuer.Domain != "" && strict {
+			return nil, fielderror.Errorf(fielderror.Index(field, i), "unknown CA issuer domain %q", issuer.Domain)
+		} else {
+			issuer.Domain = strings.ToLower(issuer.Domain)
+		}
+		r = append(r, issuer.String())
 	}
-
 	return r, nil
 }
 
@@ -86,7 +241,7 @@ func CAABuilderString(value CAAConfig) ([]string, error) {
 
 	r := []string{}
 	for _, record := range records {
-		r = append(r, strconv.Itoa(record.Flag)+" "+record.Tag+" "+`"`+record.Value+`"`)
+		r = append(r, record.String())
 	}
 
 	return r, nil
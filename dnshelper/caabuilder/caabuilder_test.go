@@ -9,6 +9,15 @@ import (
 	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
 )
 
+// issuers parses issuer values for test configs.
+func issuers(values ...string) []caabuilder.Issuer {
+	r, err := caabuilder.ParseIssuers(values)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
 func TestCAABuilder_NoIssue(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -58,7 +67,7 @@ func TestCAABuilderString(t *testing.T) {
 		{
 			name: "Single Issue",
 			args: caabuilder.CAAConfig{
-				Issue: []string{"letsencrypt.org"},
+				Issue: issuers("letsencrypt.org"),
 			},
 			want: []string{
 				`0 issue "letsencrypt.org"`,
@@ -68,10 +77,10 @@ func TestCAABuilderString(t *testing.T) {
 		{
 			name: "Multiple Records",
 			args: caabuilder.CAAConfig{
-				Iodef:             "mailto:security@example.com",
+				Iodef:             []string{"mailto:security@example.com"},
 				IodefCritical:     true,
-				Issue:             []string{"letsencrypt.org"},
-				Issuewild:         []string{"sectigo.com"},
+				Issue:             issuers("letsencrypt.org"),
+				Issuewild:         issuers("sectigo.com"),
 				IssuewildCritical: true,
 			},
 			want: []string{
@@ -85,10 +94,10 @@ func TestCAABuilderString(t *testing.T) {
 		{
 			name: "Multiple Records with IodefCritical false",
 			args: caabuilder.CAAConfig{
-				Iodef:             "mailto:security@example.com",
+				Iodef:             []string{"mailto:security@example.com"},
 				IodefCritical:     false,
-				Issue:             []string{"letsencrypt.org"},
-				Issuewild:         []string{"sectigo.com"},
+				Issue:             issuers("letsencrypt.org"),
+				Issuewild:         issuers("sectigo.com"),
 				IssueCritical:     false,
 				IssuewildCritical: true,
 			},
@@ -102,10 +111,10 @@ func TestCAABuilderString(t *testing.T) {
 		{
 			name: "Multiple Records with IodefCritical false and IssueCritical true",
 			args: caabuilder.CAAConfig{
-				Iodef:             "mailto:security@example.com",
+				Iodef:             []string{"mailto:security@example.com"},
 				IodefCritical:     false,
-				Issue:             []string{"letsencrypt.org"},
-				Issuewild:         []string{"sectigo.com"},
+				Issue:             issuers("letsencrypt.org"),
+				Issuewild:         issuers("sectigo.com"),
 				IssueCritical:     true,
 				IssuewildCritical: true,
 			},
@@ -119,8 +128,8 @@ func TestCAABuilderString(t *testing.T) {
 		{
 			name: "None Values",
 			args: caabuilder.CAAConfig{
-				Issue:     []string{"none"},
-				Issuewild: []string{"none"},
+				Issue:     issuers("none"),
+				Issuewild: issuers("none"),
 			},
 			want: []string{
 				`0 issue ";"`,
@@ -128,6 +137,395 @@ func TestCAABuilderString(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Issuer Parameters",
+			args: caabuilder.CAAConfig{
+				Issue:     issuers("letsencrypt.org;accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234;validationmethods=dns-01"),
+				Issuewild: issuers("none"),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01"`,
+				`0 issuewild ";"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Mail And VMC Issuers",
+			args: caabuilder.CAAConfig{
+				Issue:             issuers("letsencrypt.org"),
+				Issuemail:         issuers("sectigo.com"),
+				IssuemailCritical: true,
+				Issuevmc:          issuers("digicert.com", "entrust.net"),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`128 issuemail "sectigo.com"`,
+				`0 issuevmc "digicert.com"`,
+				`0 issuevmc "entrust.net"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Mail And VMC None",
+			args: caabuilder.CAAConfig{
+				Issue:            issuers("letsencrypt.org"),
+				Issuemail:        issuers("none"),
+				Issuevmc:         issuers("none"),
+				IssuevmcCritical: true,
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 issuemail ";"`,
+				`128 issuevmc ";"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Mail Issuer Only",
+			args: caabuilder.CAAConfig{
+				Iodef:     []string{"mailto:security@example.com"},
+				Issuemail: issuers("sectigo.com"),
+			},
+			want: []string{
+				`0 iodef "mailto:security@example.com"`,
+				`0 issuemail "sectigo.com"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "VMC Issuer Only",
+			args: caabuilder.CAAConfig{
+				Issuevmc: issuers("digicert.com"),
+			},
+			want: []string{
+				`0 issuevmc "digicert.com"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid Mail Issuer",
+			args: caabuilder.CAAConfig{
+				Issue:     issuers("letsencrypt.org"),
+				Issuemail: []caabuilder.Issuer{{Domain: "sectigo.com"}, {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Contacts",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactemail: []string{"security@example.com", "dns+caa@example.com"},
+				Contactphone: []string{"+14155550100"},
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 contactemail "security@example.com"`,
+				`0 contactemail "dns+caa@example.com"`,
+				`0 contactphone "+14155550100"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Contact Email With Display Name",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactemail: []string{"Security <security@example.com>"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Contact Email Without Domain",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactemail: []string{"security"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Contact Phone Without Plus",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactphone: []string{"14155550100"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Contact Phone Too Long",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactphone: []string{"+1415555010012345"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Contact Phone With Separators",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactphone: []string{"+1 415-555-0100"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Multiple Iodef Targets",
+			args: caabuilder.CAAConfig{
+				Iodef:         []string{"mailto:security@example.com", "https://iodef.example.com/report"},
+				IodefCritical: true,
+				Issue:         issuers("letsencrypt.org"),
+			},
+			want: []string{
+				`128 iodef "mailto:security@example.com"`,
+				`128 iodef "https://iodef.example.com/report"`,
+				`0 issue "letsencrypt.org"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Iodef Mailto Prefix",
+			args: caabuilder.CAAConfig{
+				Iodef:       []string{"security@example.com", "http://iodef.example.com/"},
+				IodefMailto: true,
+				Issue:       issuers("letsencrypt.org"),
+			},
+			want: []string{
+				`0 iodef "mailto:security@example.com"`,
+				`0 iodef "http://iodef.example.com/"`,
+				`0 issue "letsencrypt.org"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Iodef Bare Email",
+			args: caabuilder.CAAConfig{
+				Iodef: []string{"security@example.com"},
+				Issue: issuers("letsencrypt.org"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Iodef Unsupported Scheme",
+			args: caabuilder.CAAConfig{
+				Iodef: []string{"ftp://iodef.example.com/"},
+				Issue: issuers("letsencrypt.org"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Iodef Invalid Mailto",
+			args: caabuilder.CAAConfig{
+				Iodef: []string{"mailto:security"},
+				Issue: issuers("letsencrypt.org"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Iodef Without Host",
+			args: caabuilder.CAAConfig{
+				Iodef: []string{"https:///report"},
+				Issue: issuers("letsencrypt.org"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid Issuer Parameter",
+			args: caabuilder.CAAConfig{
+				Issue: []caabuilder.Issuer{{Domain: "letsencrypt.org"}, {Domain: "sectigo.com", ValidationMethods: []string{"dns 01"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "None Mixed With Issuers",
+			args: caabuilder.CAAConfig{
+				Issue: []caabuilder.Issuer{{Domain: "letsencrypt.org"}, {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Issuer Aliases",
+			args: caabuilder.CAAConfig{
+				Issue:     issuers("LetsEncrypt", "lets-encrypt; validationmethods=dns-01", "amazontrust.com"),
+				Issuewild: issuers("digicert"),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 issue "letsencrypt.org; validationmethods=dns-01"`,
+				`0 issue "amazontrust.com"`,
+				`0 issuewild "digicert.com"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Duplicates Kept By Default",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org", "letsencrypt"),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 issue "letsencrypt.org"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Dedup",
+			args: caabuilder.CAAConfig{
+				Iodef:     []string{"mailto:security@example.com", "mailto:security@example.com"},
+				Issue:     issuers("sectigo.com", "letsencrypt", "sectigo.com", "letsencrypt.org;", "CA.Example.com", "ca.example.com"),
+				Issuewild: issuers("sectigo.com"),
+				Dedup:     true,
+			},
+			want: []string{
+				`0 iodef "mailto:security@example.com"`,
+				`0 issue "sectigo.com"`,
+				`0 issue "letsencrypt.org"`,
+				`0 issue "ca.example.com"`,
+				`0 issuewild "sectigo.com"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sort",
+			args: caabuilder.CAAConfig{
+				Iodef:        []string{"mailto:security@example.com"},
+				Issue:        issuers("sectigo.com", "letsencrypt.org", "digicert.com"),
+				Issuewild:    issuers("pki.goog", "amazon.com"),
+				Contactemail: []string{"security@example.com"},
+				Sort:         true,
+			},
+			want: []string{
+				`0 contactemail "security@example.com"`,
+				`0 iodef "mailto:security@example.com"`,
+				`0 issue "digicert.com"`,
+				`0 issue "letsencrypt.org"`,
+				`0 issue "sectigo.com"`,
+				`0 issuewild "amazon.com"`,
+				`0 issuewild "pki.goog"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sort And Dedup",
+			args: caabuilder.CAAConfig{
+				Issue:         issuers("sectigo.com", "LetsEncrypt.org", "sectigo.com"),
+				IssueCritical: true,
+				Dedup:         true,
+				Sort:          true,
+			},
+			want: []string{
+				`128 issue "letsencrypt.org"`,
+				`128 issue "sectigo.com"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Entry Critical",
+			args: caabuilder.CAAConfig{
+				Iodef:         []string{"mailto:security@example.com"},
+				Issue:         issuers("letsencrypt.org", "pki.goog"),
+				IssueCritical: true,
+				Issuewild:     issuers("sectigo.com"),
+				EntryCritical: map[string][]bool{"issue": {false, true}, "iodef": {true}},
+			},
+			want: []string{
+				`128 iodef "mailto:security@example.com"`,
+				`0 issue "letsencrypt.org"`,
+				`128 issue "pki.goog"`,
+				`0 issuewild "sectigo.com"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Entry Critical Length Mismatch",
+			args: caabuilder.CAAConfig{
+				Issue:         issuers("letsencrypt.org", "pki.goog"),
+				EntryCritical: map[string][]bool{"issue": {true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Entry Critical Unsupported Tag",
+			args: caabuilder.CAAConfig{
+				Issue:         issuers("letsencrypt.org"),
+				Contactemail:  []string{"security@example.com"},
+				EntryCritical: map[string][]bool{"contactemail": {true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Extra Records",
+			args: caabuilder.CAAConfig{
+				Issue:        issuers("letsencrypt.org"),
+				Contactphone: []string{"+14155550100"},
+				Extra: []caabuilder.CAARecord{
+					caabuilder.CAA("TBS", "unknown", 128),
+					caabuilder.CAA("policy", `v=1; note="quoted"`, 0),
+				},
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 contactphone "+14155550100"`,
+				`128 tbs "unknown"`,
+				`0 policy "v=1; note=\"quoted\""`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Extra Known Tag",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org"),
+				Extra: []caabuilder.CAARecord{caabuilder.CAA("issuewild", "sectigo.com", 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Extra Tag Too Long",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org"),
+				Extra: []caabuilder.CAARecord{caabuilder.CAA("experimentaltag1", "x", 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Extra Tag Not Alphanumeric",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org"),
+				Extra: []caabuilder.CAARecord{caabuilder.CAA("x-tag", "x", 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Extra Unsupported Flags",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org"),
+				Extra: []caabuilder.CAARecord{caabuilder.CAA("tbs", "x", 1)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Extra Control Character",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org"),
+				Extra: []caabuilder.CAARecord{caabuilder.CAA("tbs", "a\nb", 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Issuer",
+			args: caabuilder.CAAConfig{
+				Issue: issuers("ca.example.net", "letsencrypt"),
+			},
+			want: []string{
+				`0 issue "ca.example.net"`,
+				`0 issue "letsencrypt.org"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown Issuer Strict",
+			args: caabuilder.CAAConfig{
+				Issue:         issuers("letsencrypt.org", "ca.example.net"),
+				StrictIssuers: true,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,222 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+type Resolver interface {
+	// GetCAA returns the CAA records at name itself, without following CNAMEs.
+	GetCAA(name string) ([]string, error)
+	// GetCNAME returns the CNAME target of name, empty when it is not an alias.
+	GetCNAME(name string) (string, error)
+}
+
+// maxCNAMEChain bounds how many aliases are followed from a single name.
+const maxCNAMEChain = 8
+
+// knownTags are the property tags understood by the evaluator. A critical
+// record with any other tag forbids issuance (RFC 8659 section 4.1).
+var knownTags = map[string]bool{
+	"issue":        true,
+	"issuewild":    true,
+	"iodef":        true,
+	"issuemail":    true,
+	"issuevmc":     true,
+	"contactemail": true,
+	"contactphone": true,
+}
+
+// CheckRequest describes a certificate request to evaluate against CAA.
+type CheckRequest struct {
+	// Hostname is the domain name the certificate is requested for, with a
+	// leading "*." for wildcard certificates.
+	Hostname string
+	// Issuer is the issuer domain name of the CA, e.g. "letsencrypt.org".
+	Issuer string
+	// AccountURI and ValidationMethod are matched against the RFC 8657
+	// parameters of the issuer records when set.
+	AccountURI       string
+	ValidationMethod string
+}
+
+// CheckResult is the outcome of a CAA evaluation.
+type CheckResult struct {
+	Allowed bool
+	// Reason explains the decision.
+	Reason string
+	// RecordName is where the relevant record set was found, empty when no
+	// name up to the top-level domain publishes CAA records.
+	RecordName string
+	// Alias is the target name the records were found at when RecordName is
+	// an alias, and empty otherwise.
+	Alias string
+	// Records is the relevant record set in presentation format.
+	Records []CAARecord
+	// Tag is the property tag that decided the outcome, "issue" or
+	// "issuewild", or empty when no property restricted issuance.
+	Tag string
+}
+
+// RelevantRecordSet returns the relevant CAA record set of hostname (RFC 8659
+// section 3), with the name it was found at and the alias it was read from.
+func RelevantRecordSet(hostname string, resolver Resolver) (string, string, []CAARecord, error) {
+	name := normalizeHostname(strings.TrimPrefix(strings.TrimSpace(hostname), "*."))
+	if name == "" {
+		return "", "", nil, fielderror.New("Hostname", "hostname must not be empty")
+	}
+
+	for ; name != ""; name = parentName(name) {
+		target, values, err := lookupCAA(name, resolver)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		records := make([]CAARecord, 0, len(values))
+		for _, value := range values {
+			record, err := ParseRecord(value)
+			if err != nil {
+				return "", "", nil, fmt.Errorf("invalid CAA record at %s: %w", target, err)
+			}
+			records = append(records, record)
+		}
+		if target == name {
+			target = ""
+		}
+		return name, target, records, nil
+	}
+
+	return "", "", nil, nil
+}
+
+// lookupCAA returns the CAA records of name, following CNAME records, along
+// with the name they were found at.
+func lookupCAA(name string, resolver Resolver) (string, []string, error) {
+	target := name
+	for range maxCNAMEChain + 1 {
+		values, err := resolver.GetCAA(target)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to look up CAA records of %s: %w", target, err)
+		}
+		if len(values) > 0 {
+			return target, values, nil
+		}
+
+		alias, err := resolver.GetCNAME(target)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to look up CNAME record of %s: %w", target, err)
+		}
+		if alias == "" {
+			return target, nil, nil
+		}
+		target = normalizeHostname(alias)
+	}
+	return "", nil, fmt.Errorf("CNAME chain from %s is longer than %d records", name, maxCNAMEChain)
+}
+
+// Check evaluates whether request.Issuer may issue a certificate for
+// request.Hostname following RFC 8659 section 4 and RFC 8657.
+func Check(request CheckRequest, resolver Resolver) (CheckResult, error) {
+	issuer := normalizeHostname(request.Issuer)
+	if issuer == "" {
+		return CheckResult{}, fielderror.New("Issuer", "issuer must not be empty")
+	}
+	if !isIssuerDomainName(issuer) {
+		return CheckResult{}, fielderror.Errorf("Issuer", "invalid issuer domain name %q", request.Issuer)
+	}
+	// A well-known CA is authorized by any of its issuer domain names.
+	domains := []string{issuer}
+	if ca, domain, ok := LookupIssuer(issuer); ok {
+		issuer, domains = domain, ca.Domains
+	}
+
+	name, alias, records, err := RelevantRecordSet(request.Hostname, resolver)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	r := CheckResult{RecordName: name, Alias: alias, Records: records}
+	if name == "" {
+		r.Allowed, r.Reason = true, "no CAA records are published for the hostname or its parents"
+		return r, nil
+	}
+
+	for _, record := range records {
+		if record.Flag&caaCritical != 0 && !knownTags[record.Tag] {
+			r.Reason = fmt.Sprintf("critical CAA record with unknown tag %q at %s", record.Tag, name)
+			return r, nil
+		}
+	}
+
+	wildcard := strings.HasPrefix(strings.TrimSpace(request.Hostname), "*.")
+	r.Tag = "issue"
+	if wildcard && hasTag(records, "issuewild") {
+		r.Tag = "issuewild"
+	}
+	if !hasTag(records, r.Tag) {
+		r.Allowed, r.Reason, r.Tag = true, fmt.Sprintf("the CAA records at %s do not restrict issuance", name), ""
+		return r, nil
+	}
+
+	for _, record := range records {
+		if record.Tag != r.Tag {
+			continue
+		}
+		// Malformed values are treated as forbidding issuance.
+		i, err := ParseIssuer(record.Value)
+		if err != nil || !containsFold(domains, i.Domain) {
+			continue
+		}
+		if i.AccountURI != "" && i.AccountURI != request.AccountURI {
+			continue
+		}
+		if i.ValidationMethods != nil && !containsFold(i.ValidationMethods, request.ValidationMethod) {
+			continue
+		}
+		r.Allowed, r.Reason = true, fmt.Sprintf("%s record at %s authorizes %s", r.Tag, name, issuer)
+		return r, nil
+	}
+
+	r.Reason = fmt.Sprintf("no %s record at %s authorizes %s", r.Tag, name, issuer)
+	if request.AccountURI != "" || request.ValidationMethod != "" {
+		r.Reason += " with the requested account and validation method"
+	}
+	return r, nil
+}
+
+func hasTag(records []CAARecord, tag string) bool {
+	for _, record := range records {
+		if record.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if value != "" && strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// parentName returns the parent of name, or an empty string for a top-level
+// domain.
+func parentName(name string) string {
+	_, parent, _ := strings.Cut(name, ".")
+	return parent
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+}
@@ -0,0 +1,256 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+type testResolver struct {
+	caa   map[string][]string
+	cname map[string]string
+}
+
+func (r testResolver) GetCAA(name string) ([]string, error) {
+	if name == "servfail.example" {
+		return nil, errors.New("SERVFAIL")
+	}
+	return r.caa[name], nil
+}
+
+func (r testResolver) GetCNAME(name string) (string, error) {
+	return r.cname[name], nil
+}
+
+var checkResolver = testResolver{
+	caa: map[string][]string{
+		"example.com": {
+			`0 issue "letsencrypt.org"`,
+			`0 issue "pki.goog; accounturi=https://dv.acme-v02.api.pki.goog/account/1; validationmethods=dns-01"`,
+			`0 issuewild ";"`,
+			`0 iodef "mailto:security@example.com"`,
+		},
+		"shop.example.com":   {`0 iodef "mailto:security@example.com"`},
+		"cdn.example.net":    {`0 issue "digicert.com"`},
+		"aws.example.net":    {`0 issue "amazontrust.com"`},
+		"strict.example.com": {`0 issue "letsencrypt.org"`, `128 tbs "unknown"`},
+		"broken.example.com": {`0 issue "letsencrypt.org; =x"`},
+		"loop.example.com":   nil,
+	},
+	cname: map[string]string{
+		"www.example.org":  "cdn.example.net.",
+		"loop.example.org": "loop.example.org",
+	},
+}
+
+func TestRelevantRecordSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		hostname  string
+		wantName  string
+		wantAlias string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:     "Own Records",
+			hostname: "example.com",
+			wantName: "example.com",
+			want:     []string{`0 issue "letsencrypt.org"`, `0 issue "pki.goog; accounturi=https://dv.acme-v02.api.pki.goog/account/1; validationmethods=dns-01"`, `0 issuewild ";"`, `0 iodef "mailto:security@example.com"`},
+		},
+		{
+			name:     "Parent Records",
+			hostname: "*.a.b.Example.COM.",
+			wantName: "example.com",
+			want:     []string{`0 issue "letsencrypt.org"`, `0 issue "pki.goog; accounturi=https://dv.acme-v02.api.pki.goog/account/1; validationmethods=dns-01"`, `0 issuewild ";"`, `0 iodef "mailto:security@example.com"`},
+		},
+		{
+			name:     "Closest Records Win",
+			hostname: "www.shop.example.com",
+			wantName: "shop.example.com",
+			want:     []string{`0 iodef "mailto:security@example.com"`},
+		},
+		{
+			name:      "CNAME",
+			hostname:  "www.example.org",
+			wantName:  "www.example.org",
+			wantAlias: "cdn.example.net",
+			want:      []string{`0 issue "digicert.com"`},
+		},
+		{
+			name:     "No Records",
+			hostname: "host.example.invalid",
+		},
+		{
+			name:     "Empty Hostname",
+			hostname: " ",
+			wantErr:  true,
+		},
+		{
+			name:     "CNAME Loop",
+			hostname: "loop.example.org",
+			wantErr:  true,
+		},
+		{
+			name:     "Lookup Error",
+			hostname: "www.servfail.example",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, alias, records, err := caabuilder.RelevantRecordSet(tt.hostname, checkResolver)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RelevantRecordSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, record := range records {
+				got = append(got, record.String())
+			}
+			if name != tt.wantName || alias != tt.wantAlias || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RelevantRecordSet() = %q, %q, %q, want %q, %q, %q", name, alias, got, tt.wantName, tt.wantAlias, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     caabuilder.CheckRequest
+		wantAllowed bool
+		wantTag     string
+		wantRecord  string
+		wantReason  string
+		wantErr     bool
+	}{
+		{
+			name:        "Authorized Issuer",
+			request:     caabuilder.CheckRequest{Hostname: "www.example.com", Issuer: "LetsEncrypt.org"},
+			wantAllowed: true,
+			wantTag:     "issue",
+			wantRecord:  "example.com",
+			wantReason:  "issue record at example.com authorizes letsencrypt.org",
+		},
+		{
+			name:       "Unauthorized Issuer",
+			request:    caabuilder.CheckRequest{Hostname: "www.example.com", Issuer: "digicert.com"},
+			wantTag:    "issue",
+			wantRecord: "example.com",
+			wantReason: "no issue record at example.com authorizes digicert.com",
+		},
+		{
+			name:       "Wildcard Uses Issuewild",
+			request:    caabuilder.CheckRequest{Hostname: "*.example.com", Issuer: "letsencrypt.org"},
+			wantTag:    "issuewild",
+			wantRecord: "example.com",
+			wantReason: "no issuewild record at example.com authorizes letsencrypt.org",
+		},
+		{
+			name:        "Wildcard Falls Back To Issue",
+			request:     caabuilder.CheckRequest{Hostname: "*.www.example.org", Issuer: "digicert.com"},
+			wantAllowed: true,
+			wantTag:     "issue",
+			wantRecord:  "www.example.org",
+			wantReason:  "issue record at www.example.org authorizes digicert.com",
+		},
+		{
+			name:        "Account And Method Match",
+			request:     caabuilder.CheckRequest{Hostname: "example.com", Issuer: "pki.goog", AccountURI: "https://dv.acme-v02.api.pki.goog/account/1", ValidationMethod: "DNS-01"},
+			wantAllowed: true,
+			wantTag:     "issue",
+			wantRecord:  "example.com",
+			wantReason:  "issue record at example.com authorizes pki.goog",
+		},
+		{
+			name:       "Account Mismatch",
+			request:    caabuilder.CheckRequest{Hostname: "example.com", Issuer: "pki.goog", AccountURI: "https://dv.acme-v02.api.pki.goog/account/2", ValidationMethod: "dns-01"},
+			wantTag:    "issue",
+			wantRecord: "example.com",
+			wantReason: "no issue record at example.com authorizes pki.goog with the requested account and validation method",
+		},
+		{
+			name:       "Validation Method Mismatch",
+			request:    caabuilder.CheckRequest{Hostname: "example.com", Issuer: "pki.goog", AccountURI: "https://dv.acme-v02.api.pki.goog/account/1", ValidationMethod: "http-01"},
+			wantTag:    "issue",
+			wantRecord: "example.com",
+			wantReason: "no issue record at example.com authorizes pki.goog with the requested account and validation method",
+		},
+		{
+			name:       "Validation Method Required",
+			request:    caabuilder.CheckRequest{Hostname: "example.com", Issuer: "pki.goog", AccountURI: "https://dv.acme-v02.api.pki.goog/account/1"},
+			wantTag:    "issue",
+			wantRecord: "example.com",
+			wantReason: "no issue record at example.com authorizes pki.goog with the requested account and validation method",
+		},
+		{
+			name:        "Issuer Alias",
+			request:     caabuilder.CheckRequest{Hostname: "aws.example.net", Issuer: "amazon"},
+			wantAllowed: true,
+			wantTag:     "issue",
+			wantRecord:  "aws.example.net",
+			wantReason:  "issue record at aws.example.net authorizes amazon.com",
+		},
+		{
+			name:        "No Issue Property",
+			request:     caabuilder.CheckRequest{Hostname: "shop.example.com", Issuer: "digicert.com"},
+			wantAllowed: true,
+			wantRecord:  "shop.example.com",
+			wantReason:  "the CAA records at shop.example.com do not restrict issuance",
+		},
+		{
+			name:        "No Records",
+			request:     caabuilder.CheckRequest{Hostname: "host.example.invalid", Issuer: "digicert.com"},
+			wantAllowed: true,
+			wantReason:  "no CAA records are published for the hostname or its parents",
+		},
+		{
+			name:       "Critical Unknown Tag",
+			request:    caabuilder.CheckRequest{Hostname: "strict.example.com", Issuer: "letsencrypt.org"},
+			wantRecord: "strict.example.com",
+			wantReason: `critical CAA record with unknown tag "tbs" at strict.example.com`,
+		},
+		{
+			name:       "Malformed Issuer Value",
+			request:    caabuilder.CheckRequest{Hostname: "broken.example.com", Issuer: "letsencrypt.org"},
+			wantTag:    "issue",
+			wantRecord: "broken.example.com",
+			wantReason: "no issue record at broken.example.com authorizes letsencrypt.org",
+		},
+		{
+			name:    "Missing Issuer",
+			request: caabuilder.CheckRequest{Hostname: "example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Issuer",
+			request: caabuilder.CheckRequest{Hostname: "example.com", Issuer: "https://letsencrypt.org"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := caabuilder.Check(tt.request, checkResolver)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Allowed != tt.wantAllowed || got.Tag != tt.wantTag || got.RecordName != tt.wantRecord || got.Reason != tt.wantReason {
+				t.Errorf("Check() = %v, %q, %q, %q, want %v, %q, %q, %q", got.Allowed, got.Tag, got.RecordName, got.Reason, tt.wantAllowed, tt.wantTag, tt.wantRecord, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// e164 matches an E.164 number in international format: a leading '+' and at
+// most 15 digits, the first of which is a country code digit.
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// validateContactEmail checks that email is a bare RFC 5322 addr-spec without
+// a quoted local part, which cannot be written inside the CAA value.
+func validateContactEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email || strings.ContainsAny(email, "\" \t") {
+		return fmt.Errorf("invalid contact email %q, must be an RFC 5322 address such as security@example.com", email)
+	}
+	return nil
+}
+
+// validateContactPhone checks that phone is an E.164 number such as
+// +14155550100.
+func validateContactPhone(phone string) error {
+	if !e164.MatchString(phone) {
+		return fmt.Errorf("invalid contact phone %q, must be an E.164 number such as +14155550100", phone)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// validateEntryCritical checks that entryCritical only holds tags with
+// per-entry flags, each with one flag per entry as counted in entries.
+func validateEntryCritical(entryCritical map[string][]bool, entries map[string]int) error {
+	tags := make([]string, 0, len(entryCritical))
+	for tag := range entryCritical {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		n, ok := entries[tag]
+		if !ok {
+			return fielderror.Errorf("EntryCritical", "per-entry critical flags are not supported for tag %q", tag)
+		}
+		if len(entryCritical[tag]) != n {
+			return fielderror.Errorf("EntryCritical", "%s has %d entries but %d critical flags", tag, n, len(entryCritical[tag]))
+		}
+	}
+	return nil
+}
+
+func criticalFlag(critical bool) int {
+	if critical {
+		return caaCritical
+	}
+	return 0
+}
+
+// extraRecords validates records with tags the builder does not know about
+// and returns them with their tags in lower case.
+func extraRecords(records []CAARecord) ([]CAARecord, error) {
+	r := make([]CAARecord, 0, len(records))
+	for i, record := range records {
+		field := fielderror.Index("Extra", i)
+
+		if err := validateTag(record.Tag); err != nil {
+			return nil, fielderror.Wrap(field, err)
+		}
+		tag := strings.ToLower(record.Tag)
+		if knownTags[tag] {
+			return nil, fielderror.Errorf(field, "tag %q is built from its own attribute and must not be listed in extra", tag)
+		}
+		if record.Flag != 0 && record.Flag != caaCritical {
+			return nil, fielderror.Errorf(field, "unsupported CAA flags %d, must be 0 or %d", record.Flag, caaCritical)
+		}
+		if strings.IndexFunc(record.Value, unicode.IsControl) >= 0 {
+			return nil, fielderror.Errorf(field, "invalid CAA value %q, must not contain control characters", record.Value)
+		}
+
+		r = append(r, CAA(tag, record.Value, record.Flag))
+	}
+	return r, nil
+}
@@ -0,0 +1,62 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// iodefValues validates the iodef targets and returns them with bare email
+// addresses prefixed with mailto: when mailto is set.
+func iodefValues(values []string, mailto bool) ([]string, error) {
+	r := make([]string, 0, len(values))
+	for i, value := range values {
+		if mailto && !strings.Contains(value, ":") && strings.Contains(value, "@") {
+			value = "mailto:" + value
+		}
+		if err := validateIodef(value); err != nil {
+			return nil, fielderror.Wrap(fielderror.Index("Iodef", i), err)
+		}
+		r = append(r, value)
+	}
+	return r, nil
+}
+
+// validateIodef checks that value is a mailto: URL with a single email address
+// or an http: or https: URL, the schemes allowed by RFC 8659 section 4.4.
+func validateIodef(value string) error {
+	if strings.ContainsAny(value, "\" \t") {
+		return fmt.Errorf("invalid iodef URL %q, must not contain quotes or whitespace", value)
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid iodef URL %q: %w", value, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "mailto":
+		addr, err := mail.ParseAddress(u.Opaque)
+		if err != nil || addr.Address != u.Opaque {
+			return fmt.Errorf("invalid iodef URL %q, mailto: must be followed by an email address", value)
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("invalid iodef URL %q, must include a host", value)
+		}
+	case "":
+		if strings.Contains(value, "@") {
+			return fmt.Errorf("invalid iodef URL %q, email addresses must be prefixed with mailto:", value)
+		}
+		return fmt.Errorf("invalid iodef URL %q, must be a mailto:, http: or https: URL", value)
+	default:
+		return fmt.Errorf("invalid iodef URL %q, scheme must be mailto, http or https", value)
+	}
+	return nil
+}
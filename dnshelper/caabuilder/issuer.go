@@ -0,0 +1,225 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// Issuer is the value of an issue or issuewild property (RFC 8659 section
+// 4.2). An Issuer without a Domain forbids issuance.
+type Issuer struct {
+	Domain            string
+	AccountURI        string
+	ValidationMethods []string
+	Parameters        []IssuerParameter
+}
+
+type IssuerParameter struct {
+	Tag   string
+	Value string
+}
+
+const (
+	paramAccountURI        = "accounturi"
+	paramValidationMethods = "validationmethods"
+)
+
+// ParseIssuer parses an issue or issuewild property value such as
+// "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1; validationmethods=dns-01".
+func ParseIssuer(value string) (Issuer, error) {
+	var issuer Issuer
+
+	parts := strings.Split(value, ";")
+	issuer.Domain = strings.TrimSpace(parts[0])
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tag, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return Issuer{}, fmt.Errorf("invalid issuer parameter %q, must be tag=value", part)
+		}
+		tag, v = strings.TrimSpace(tag), strings.TrimSpace(v)
+
+		switch strings.ToLower(tag) {
+		case paramAccountURI:
+			if issuer.AccountURI != "" {
+				return Issuer{}, fmt.Errorf("issuer parameter %q must not be repeated", paramAccountURI)
+			}
+			if v == "" {
+				return Issuer{}, fmt.Errorf("issuer parameter %q must not be empty", paramAccountURI)
+			}
+			issuer.AccountURI = v
+		case paramValidationMethods:
+			if issuer.ValidationMethods != nil {
+				return Issuer{}, fmt.Errorf("issuer parameter %q must not be repeated", paramValidationMethods)
+			}
+			issuer.ValidationMethods = strings.Split(v, ",")
+		default:
+			issuer.Parameters = append(issuer.Parameters, IssuerParameter{Tag: tag, Value: v})
+		}
+	}
+
+	if err := issuer.Validate(); err != nil {
+		return Issuer{}, err
+	}
+	return issuer, nil
+}
+
+// ParseIssuers parses issuer values with ParseIssuer, "none" standing for the
+// Issuer without domain name. Errors carry the index of the offending value.
+func ParseIssuers(values []string) ([]Issuer, error) {
+	var r []Issuer
+	for i, value := range values {
+		if value == "none" {
+			if len(values) > 1 {
+				return nil, fielderror.New(fielderror.Index("", i), `"none" must be the only entry`)
+			}
+			r = append(r, Issuer{})
+			continue
+		}
+		issuer, err := ParseIssuer(value)
+		if err != nil {
+			return nil, fielderror.Wrap(fielderror.Index("", i), err)
+		}
+		r = append(r, issuer)
+	}
+	return r, nil
+}
+
+// Validate checks the issuer against the issuer-value grammar of RFC 8659 and
+// the parameter syntax of RFC 8657.
+func (i Issuer) Validate() error {
+	if i.Domain != "" && !isIssuerDomainName(i.Domain) {
+		return fmt.Errorf("invalid issuer domain name %q", i.Domain)
+	}
+
+	if i.AccountURI != "" {
+		u, err := url.Parse(i.AccountURI)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("invalid %s %q, must be an absolute URI", paramAccountURI, i.AccountURI)
+		}
+		if !isParameterValue(i.AccountURI) {
+			return fmt.Errorf("invalid %s %q, must not contain spaces or ';'", paramAccountURI, i.AccountURI)
+		}
+	}
+
+	for _, method := range i.ValidationMethods {
+		if !isValidationMethod(method) {
+			return fmt.Errorf("invalid validation method %q, must only contain letters, digits and '-'", method)
+		}
+	}
+
+	seen := map[string]bool{paramAccountURI: true, paramValidationMethods: true}
+	for _, p := range i.Parameters {
+		if !isParameterTag(p.Tag) {
+			return fmt.Errorf("invalid issuer parameter tag %q, must only contain letters and digits", p.Tag)
+		}
+		if seen[strings.ToLower(p.Tag)] {
+			return fmt.Errorf("issuer parameter %q must not be repeated", p.Tag)
+		}
+		seen[strings.ToLower(p.Tag)] = true
+		if !isParameterValue(p.Value) {
+			return fmt.Errorf("invalid value %q for issuer parameter %q, must not contain spaces or ';'", p.Value, p.Tag)
+		}
+	}
+
+	return nil
+}
+
+// String returns the issuer as an issue or issuewild property value.
+func (i Issuer) String() string {
+	params := []string{}
+	if i.AccountURI != "" {
+		params = append(params, paramAccountURI+"="+i.AccountURI)
+	}
+	if len(i.ValidationMethods) > 0 {
+		params = append(params, paramValidationMethods+"="+strings.Join(i.ValidationMethods, ","))
+	}
+	for _, p := range i.Parameters {
+		params = append(params, p.Tag+"="+p.Value)
+	}
+
+	if len(params) == 0 {
+		if i.Domain == "" {
+			return ";"
+		}
+		return i.Domain
+	}
+	return i.Domain + "; " + strings.Join(params, "; ")
+}
+
+// isIssuerDomainName reports whether name matches issuer-domain-name from
+// RFC 8659 section 4.2: labels of letters and digits, with inner hyphens.
+func isIssuerDomainName(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		if !isLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLabel(label string) bool {
+	if label == "" {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if c == '-' && i > 0 && i < len(label)-1 {
+			continue
+		}
+		if !isAlphaNum(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidationMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+	for i := 0; i < len(method); i++ {
+		if !isAlphaNum(method[i]) && method[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isParameterTag(tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for i := 0; i < len(tag); i++ {
+		if !isAlphaNum(tag[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isParameterValue reports whether value matches the parameter value grammar
+// of RFC 8659 section 4.2, printable ASCII other than space and ';'.
+func isParameterValue(value string) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c < 0x21 || c > 0x7e || c == ';' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,194 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+func TestParseIssuer(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    caabuilder.Issuer
+		wantErr bool
+	}{
+		{
+			name:  "Domain Only",
+			value: "letsencrypt.org",
+			want:  caabuilder.Issuer{Domain: "letsencrypt.org"},
+		},
+		{
+			name:  "No Issuer",
+			value: ";",
+			want:  caabuilder.Issuer{},
+		},
+		{
+			name:  "Account And Validation Methods",
+			value: "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01,http-01",
+			want: caabuilder.Issuer{
+				Domain:            "letsencrypt.org",
+				AccountURI:        "https://acme-v02.api.letsencrypt.org/acme/acct/1234",
+				ValidationMethods: []string{"dns-01", "http-01"},
+			},
+		},
+		{
+			name:  "Other Parameters And Whitespace",
+			value: " digicert.com ;cansignhttpexchanges = yes; ",
+			want: caabuilder.Issuer{
+				Domain:     "digicert.com",
+				Parameters: []caabuilder.IssuerParameter{{Tag: "cansignhttpexchanges", Value: "yes"}},
+			},
+		},
+		{
+			name:    "Invalid Domain",
+			value:   "lets_encrypt.org",
+			wantErr: true,
+		},
+		{
+			name:    "Trailing Hyphen In Label",
+			value:   "letsencrypt-.org",
+			wantErr: true,
+		},
+		{
+			name:    "Parameter Without Value Separator",
+			value:   "letsencrypt.org; accounturi",
+			wantErr: true,
+		},
+		{
+			name:    "Relative Account URI",
+			value:   "letsencrypt.org; accounturi=acct/1234",
+			wantErr: true,
+		},
+		{
+			name:    "Repeated Account URI",
+			value:   "letsencrypt.org; accounturi=https://example.com/1; accounturi=https://example.com/2",
+			wantErr: true,
+		},
+		{
+			name:    "Empty Validation Method",
+			value:   "letsencrypt.org; validationmethods=dns-01,",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Parameter Tag",
+			value:   "letsencrypt.org; can-sign=yes",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Parameter Value",
+			value:   "letsencrypt.org; policy=a b",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := caabuilder.ParseIssuer(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIssuer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIssuer() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssuer_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		issuer caabuilder.Issuer
+		want   string
+	}{
+		{
+			name:   "Domain Only",
+			issuer: caabuilder.Issuer{Domain: "letsencrypt.org"},
+			want:   "letsencrypt.org",
+		},
+		{
+			name:   "No Issuer",
+			issuer: caabuilder.Issuer{},
+			want:   ";",
+		},
+		{
+			name:   "No Issuer With Parameters",
+			issuer: caabuilder.Issuer{Parameters: []caabuilder.IssuerParameter{{Tag: "reason", Value: "decommissioned"}}},
+			want:   "; reason=decommissioned",
+		},
+		{
+			name: "All Parameters",
+			issuer: caabuilder.Issuer{
+				Domain:            "letsencrypt.org",
+				AccountURI:        "https://acme-v02.api.letsencrypt.org/acme/acct/1234",
+				ValidationMethods: []string{"dns-01"},
+				Parameters:        []caabuilder.IssuerParameter{{Tag: "cansignhttpexchanges", Value: "yes"}},
+			},
+			want: "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01; cansignhttpexchanges=yes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issuer.String(); got != tt.want {
+				t.Errorf("Issuer.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIssuers(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []string
+		want      []caabuilder.Issuer
+		wantField string
+	}{
+		{
+			name:   "Issuers",
+			values: []string{"letsencrypt.org", "sectigo.com; validationmethods=dns-01"},
+			want: []caabuilder.Issuer{
+				{Domain: "letsencrypt.org"},
+				{Domain: "sectigo.com", ValidationMethods: []string{"dns-01"}},
+			},
+		},
+		{
+			name:   "None",
+			values: []string{"none"},
+			want:   []caabuilder.Issuer{{}},
+		},
+		{
+			name:      "None Not Alone",
+			values:    []string{"letsencrypt.org", "none"},
+			wantField: "[1]",
+		},
+		{
+			name:      "Invalid Issuer",
+			values:    []string{"letsencrypt.org", "sectigo.com; validationmethods=dns 01"},
+			wantField: "[1]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := caabuilder.ParseIssuers(tt.values)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Errorf("ParseIssuers() error = %v, want field %q", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseIssuers() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIssuers() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// ParseRecord parses the RDATA of a CAA record in presentation format, e.g.
+// `0 issue "letsencrypt.org; validationmethods=dns-01"`, or in the generic
+// RFC 3597 form `\# 22 0005697373756...`. The tag is returned in lower case.
+func ParseRecord(record string) (CAARecord, error) {
+	record = strings.TrimSpace(record)
+	if strings.HasPrefix(record, `\#`) {
+		return parseGenericRecord(record)
+	}
+
+	flags, rest := cutField(record)
+	tag, rest := cutField(rest)
+	if flags == "" || tag == "" || rest == "" {
+		return CAARecord{}, fmt.Errorf("invalid CAA record %q, must be flags, tag and value", record)
+	}
+
+	flag, err := strconv.ParseUint(flags, 10, 8)
+	if err != nil {
+		return CAARecord{}, fmt.Errorf("invalid CAA flags %q, must be a number between 0 and 255", flags)
+	}
+
+	if err := validateTag(tag); err != nil {
+		return CAARecord{}, err
+	}
+
+	value, err := unquoteValue(rest)
+	if err != nil {
+		return CAARecord{}, err
+	}
+
+	return CAA(strings.ToLower(tag), value, int(flag)), nil
+}
+
+func parseGenericRecord(record string) (CAARecord, error) {
+	fields := strings.Fields(record)
+	if len(fields) < 2 || fields[0] != `\#` {
+		return CAARecord{}, fmt.Errorf(`invalid generic CAA record %q, must be \# length hex`, record)
+	}
+
+	length, err := strconv.Atoi(fields[1])
+	if err != nil || length < 0 {
+		return CAARecord{}, fmt.Errorf("invalid generic CAA record length %q", fields[1])
+	}
+
+	data, err := hex.DecodeString(strings.Join(fields[2:], ""))
+	if err != nil {
+		return CAARecord{}, fmt.Errorf("invalid generic CAA record data: %w", err)
+	}
+	if len(data) != length {
+		return CAARecord{}, fmt.Errorf("generic CAA record length is %d but has %d bytes of data", length, len(data))
+	}
+	if len(data) < 2 || len(data) < 2+int(data[1]) {
+		return CAARecord{}, fmt.Errorf("generic CAA record data is truncated")
+	}
+
+	tag := string(data[2 : 2+int(data[1])])
+	if err := validateTag(tag); err != nil {
+		return CAARecord{}, err
+	}
+
+	return CAA(strings.ToLower(tag), string(data[2+int(data[1]):]), int(data[0])), nil
+}
+
+// validateTag checks tag against RFC 8659 section 4.1: one to fifteen letters
+// and digits.
+func validateTag(tag string) error {
+	if len(tag) > 15 || !isParameterTag(tag) {
+		return fmt.Errorf("invalid CAA tag %q, must be 1 to 15 letters and digits", tag)
+	}
+	return nil
+}
+
+// cutField returns the first whitespace separated field of s and the rest of
+// s with leading whitespace removed.
+func cutField(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+// unquoteValue returns the value of a character-string, removing the quotes
+// and RFC 1035 \X and \DDD escapes.
+func unquoteValue(s string) (string, error) {
+	quoted := strings.HasPrefix(s, `"`)
+	if quoted {
+		s = s[1:]
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' && quoted:
+			if strings.TrimSpace(s[i+1:]) != "" {
+				return "", fmt.Errorf("invalid CAA value, unexpected data after closing quote: %q", s[i+1:])
+			}
+			return b.String(), nil
+		case c == '"' || !quoted && (c == ' ' || c == '\t'):
+			return "", fmt.Errorf("invalid CAA value %q, values containing spaces or quotes must be quoted", s)
+		case c != '\\':
+			b.WriteByte(c)
+		case i+1 >= len(s):
+			return "", fmt.Errorf("invalid CAA value %q, trailing backslash", s)
+		case i+3 < len(s) && isDigits(s[i+1:i+4]):
+			n, _ := strconv.Atoi(s[i+1 : i+4])
+			if n > 255 {
+				return "", fmt.Errorf("invalid CAA value %q, escape \\%s is out of range", s, s[i+1:i+4])
+			}
+			b.WriteByte(byte(n))
+			i += 3
+		default:
+			b.WriteByte(s[i+1])
+			i++
+		}
+	}
+
+	if quoted {
+		return "", fmt.Errorf("invalid CAA value %q, missing closing quote", s)
+	}
+	return b.String(), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// CAAParse parses the records of a CAA record set into the CAAConfig that
+// builds it, with per-entry critical flags in EntryCritical when the records
+// of a tag disagree, and unknown tags in Extra.
+func CAAParse(records []string) (CAAConfig, error) {
+	var config CAAConfig
+	critical := map[string]*bool{
+		"iodef":     &config.IodefCritical,
+		"issue":     &config.IssueCritical,
+		"issuewild": &config.IssuewildCritical,
+		"issuemail": &config.IssuemailCritical,
+		"issuevmc":  &config.IssuevmcCritical,
+	}
+	flags := map[string][]bool{}
+
+	for i, value := range records {
+		field := fielderror.Index("Records", i)
+
+		record, err := ParseRecord(value)
+		if err != nil {
+			return CAAConfig{}, fielderror.Wrap(field, err)
+		}
+		if record.Flag != 0 && record.Flag != caaCritical {
+			return CAAConfig{}, fielderror.Errorf(field, "unsupported CAA flags %d, must be 0 or %d", record.Flag, caaCritical)
+		}
+
+		if _, ok := critical[record.Tag]; ok {
+			flags[record.Tag] = append(flags[record.Tag], record.Flag == caaCritical)
+		} else if record.Flag != 0 && knownTags[record.Tag] {
+			return CAAConfig{}, fielderror.Errorf(field, "%s records must not be critical", record.Tag)
+		}
+
+		switch record.Tag {
+		case "iodef":
+			if err := validateIodef(record.Value); err != nil {
+				return CAAConfig{}, fielderror.Wrap(field, err)
+			}
+			config.Iodef = append(config.Iodef, record.Value)
+		case "issue", "issuewild", "issuemail", "issuevmc":
+			issuer, err := ParseIssuer(record.Value)
+			if err != nil {
+				return CAAConfig{}, fielderror.Wrap(field, err)
+			}
+			switch record.Tag {
+			case "issue":
+				config.Issue = append(config.Issue, issuer)
+			case "issuewild":
+				config.Issuewild = append(config.Issuewild, issuer)
+			case "issuemail":
+				config.Issuemail = append(config.Issuemail, issuer)
+			case "issuevmc":
+				config.Issuevmc = append(config.Issuevmc, issuer)
+			}
+		case "contactemail":
+			if err := validateContactEmail(record.Value); err != nil {
+				return CAAConfig{}, fielderror.Wrap(field, err)
+			}
+			config.Contactemail = append(config.Contactemail, record.Value)
+		case "contactphone":
+			if err := validateContactPhone(record.Value); err != nil {
+				return CAAConfig{}, fielderror.Wrap(field, err)
+			}
+			config.Contactphone = append(config.Contactphone, record.Value)
+		default:
+			config.Extra = append(config.Extra, record)
+		}
+	}
+
+	for tag, f := range flags {
+		if !slices.Contains(f, !f[0]) {
+			*critical[tag] = f[0]
+			continue
+		}
+		if config.EntryCritical == nil {
+			config.EntryCritical = map[string][]bool{}
+		}
+		config.EntryCritical[tag] = f
+	}
+
+	return config, nil
+}
@@ -0,0 +1,223 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+func TestParseRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string
+		want    caabuilder.CAARecord
+		wantErr bool
+	}{
+		{
+			name:   "Quoted Value",
+			record: `0 issue "letsencrypt.org; validationmethods=dns-01"`,
+			want:   caabuilder.CAA("issue", "letsencrypt.org; validationmethods=dns-01", 0),
+		},
+		{
+			name:   "Unquoted Value And Upper Case Tag",
+			record: `128 ISSUEWILD letsencrypt.org`,
+			want:   caabuilder.CAA("issuewild", "letsencrypt.org", 128),
+		},
+		{
+			name:   "Escapes",
+			record: `0 iodef "mailto:security\064example.com\"x"`,
+			want:   caabuilder.CAA("iodef", `mailto:security@example.com"x`, 0),
+		},
+		{
+			name:   "Empty Value",
+			record: `0 issue ""`,
+			want:   caabuilder.CAA("issue", "", 0),
+		},
+		{
+			name:   "Generic Form",
+			record: `\# 17 00056973737565 63612e6578616d706c65`,
+			want:   caabuilder.CAA("issue", "ca.example", 0),
+		},
+		{
+			name:    "Generic Form Length Mismatch",
+			record:  `\# 18 00056973737565 63612e6578616d706c65`,
+			wantErr: true,
+		},
+		{
+			name:    "Generic Form Truncated Tag",
+			record:  `\# 3 000569`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing Value",
+			record:  `0 issue`,
+			wantErr: true,
+		},
+		{
+			name:    "Flags Out Of Range",
+			record:  `256 issue "letsencrypt.org"`,
+			wantErr: true,
+		},
+		{
+			name:    "Tag Too Long",
+			record:  `0 issuecertificates "letsencrypt.org"`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing Closing Quote",
+			record:  `0 issue "letsencrypt.org`,
+			wantErr: true,
+		},
+		{
+			name:    "Data After Closing Quote",
+			record:  `0 issue "letsencrypt.org" x`,
+			wantErr: true,
+		},
+		{
+			name:    "Unquoted Value With Spaces",
+			record:  `0 issue letsencrypt.org; validationmethods=dns-01`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := caabuilder.ParseRecord(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRecord() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseRecord() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCAAParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []string
+		want    caabuilder.CAAConfig
+		wantErr bool
+	}{
+		{
+			name: "Record Set",
+			records: []string{
+				`128 iodef "mailto:security@example.com"`,
+				`0 issue "letsencrypt.org;validationmethods=dns-01"`,
+				`0 issue ";"`,
+				`128 issuewild "sectigo.com"`,
+				`0 issuemail "sectigo.com"`,
+				`0 contactemail "security@example.com"`,
+				`0 contactphone "+14155550100"`,
+			},
+			want: caabuilder.CAAConfig{
+				Iodef:             []string{"mailto:security@example.com"},
+				IodefCritical:     true,
+				Issue:             issuers("letsencrypt.org; validationmethods=dns-01", ";"),
+				Issuewild:         issuers("sectigo.com"),
+				IssuewildCritical: true,
+				Issuemail:         issuers("sectigo.com"),
+				Contactemail:      []string{"security@example.com"},
+				Contactphone:      []string{"+14155550100"},
+			},
+		},
+		{
+			name:    "Mixed Critical Flags",
+			records: []string{`0 issue "letsencrypt.org"`, `128 issue "sectigo.com"`, `128 issuewild "sectigo.com"`},
+			want: caabuilder.CAAConfig{
+				Issue:             issuers("letsencrypt.org", "sectigo.com"),
+				Issuewild:         issuers("sectigo.com"),
+				IssuewildCritical: true,
+				EntryCritical:     map[string][]bool{"issue": {false, true}},
+			},
+		},
+		{
+			name:    "Critical Contact",
+			records: []string{`0 issue "letsencrypt.org"`, `128 contactemail "security@example.com"`},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported Flags",
+			records: []string{`1 issue "letsencrypt.org"`},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Tags",
+			records: []string{`0 issue "letsencrypt.org"`, `0 tbs "unknown"`, `128 Future "x; y"`},
+			want: caabuilder.CAAConfig{
+				Issue: issuers("letsencrypt.org"),
+				Extra: []caabuilder.CAARecord{caabuilder.CAA("tbs", "unknown", 0), caabuilder.CAA("future", "x; y", 128)},
+			},
+		},
+		{
+			name:    "Invalid Issuer",
+			records: []string{`0 issue "letsencrypt.org; accounturi=acct"`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := caabuilder.CAAParse(tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CAAParse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CAAParse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCAAParse_RoundTrip(t *testing.T) {
+	config := caabuilder.CAAConfig{
+		Iodef:             []string{"mailto:security@example.com", "https://iodef.example.com/"},
+		Issue:             issuers("letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01"),
+		IssueCritical:     true,
+		Issuewild:         issuers("none"),
+		Issuevmc:          issuers("digicert.com"),
+		IssuevmcCritical:  true,
+		Contactemail:      []string{"security@example.com"},
+		IssuewildCritical: false,
+		EntryCritical:     map[string][]bool{"iodef": {true, false}},
+		Extra:             []caabuilder.CAARecord{caabuilder.CAA("tbs", "unknown", 128)},
+	}
+
+	records, err := caabuilder.CAABuilderString(config)
+	if err != nil {
+		t.Fatalf("CAABuilderString() error = %v", err)
+	}
+
+	parsed, err := caabuilder.CAAParse(records)
+	if err != nil {
+		t.Fatalf("CAAParse() error = %v", err)
+	}
+	rebuilt, err := caabuilder.CAABuilderString(parsed)
+	if err != nil {
+		t.Fatalf("CAABuilderString() error = %v", err)
+	}
+	if !reflect.DeepEqual(rebuilt, records) {
+		t.Errorf("CAABuilderString(CAAParse()) = %v, want %v", rebuilt, records)
+	}
+}
+
+func TestCAAParse_RoundTripUnknownIssuer(t *testing.T) {
+	records := []string{`0 issue "ca.internal.example"`, `0 issuewild "ca.internal.example; validationmethods=dns-01"`}
+
+	parsed, err := caabuilder.CAAParse(records)
+	if err != nil {
+		t.Fatalf("CAAParse() error = %v", err)
+	}
+	rebuilt, err := caabuilder.CAABuilderString(parsed)
+	if err != nil {
+		t.Fatalf("CAABuilderString() error = %v", err)
+	}
+	if !reflect.DeepEqual(rebuilt, records) {
+		t.Errorf("CAABuilderString(CAAParse()) = %v, want %v", rebuilt, records)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder
+
+// CertificateAuthority is a publicly trusted CA known to the issuer registry.
+type CertificateAuthority struct {
+	Name string
+	// Domains are the issuer domain names the CA recognizes in CAA records,
+	// the first one being the one it documents.
+	Domains []string
+	// Aliases are names commonly written instead of an issuer domain name
+	// that only refer to this CA. They are replaced by the first of Domains.
+	Aliases []string
+}
+
+// certificateAuthorities is the embedded registry of well-known CAs and the
+// issuer domain names they publish in their CP/CPS.
+var certificateAuthorities = []CertificateAuthority{
+	{
+		Name:    "Let's Encrypt",
+		Domains: []string{"letsencrypt.org"},
+		Aliases: []string{"letsencrypt", "lets-encrypt"},
+	},
+	{
+		Name:    "Google Trust Services",
+		Domains: []string{"pki.goog"},
+		Aliases: []string{"google", "gts", "googletrustservices"},
+	},
+	{
+		Name:    "Amazon",
+		Domains: []string{"amazon.com", "amazontrust.com", "awstrust.com", "amazonaws.com"},
+		Aliases: []string{"amazon", "aws", "acm"},
+	},
+	{
+		Name:    "DigiCert",
+		Domains: []string{"digicert.com", "www.digicert.com", "digitalcertvalidation.com", "symantec.com", "thawte.com", "geotrust.com", "rapidssl.com", "quovadisglobal.com"},
+		Aliases: []string{"digicert", "thawte", "geotrust", "rapidssl", "quovadis"},
+	},
+	{
+		Name:    "Sectigo",
+		Domains: []string{"sectigo.com", "comodoca.com", "comodo.com", "usertrust.com", "trust-provider.com"},
+		Aliases: []string{"sectigo", "comodo"},
+	},
+	{
+		Name:    "SSL.com",
+		Domains: []string{"ssl.com"},
+		Aliases: []string{"sslcom", "ssl-com"},
+	},
+	{
+		Name:    "Entrust",
+		Domains: []string{"entrust.net", "affirmtrust.com"},
+	},
+	{
+		Name:    "GlobalSign",
+		Domains: []string{"globalsign.com"},
+		Aliases: []string{"globalsign"},
+	},
+	{
+		Name:    "GoDaddy",
+		Domains: []string{"godaddy.com", "starfieldtech.com"},
+		Aliases: []string{"godaddy", "starfield"},
+	},
+	{
+		Name:    "Buypass",
+		Domains: []string{"buypass.com", "buypass.no"},
+		Aliases: []string{"buypass"},
+	},
+	{
+		Name:    "Certum",
+		Domains: []string{"certum.pl", "certum.eu"},
+		Aliases: []string{"certum"},
+	},
+	{
+		Name:    "HARICA",
+		Domains: []string{"harica.gr"},
+		Aliases: []string{"harica"},
+	},
+	{
+		Name:    "Actalis",
+		Domains: []string{"actalis.it"},
+		Aliases: []string{"actalis"},
+	},
+	{
+		Name:    "IdenTrust",
+		Domains: []string{"identrust.com"},
+		Aliases: []string{"identrust"},
+	},
+	{
+		Name:    "Certainly",
+		Domains: []string{"certainly.com"},
+		Aliases: []string{"certainly"},
+	},
+	{
+		Name:    "SwissSign",
+		Domains: []string{"swisssign.com"},
+		Aliases: []string{"swisssign"},
+	},
+	{
+		Name:    "D-TRUST",
+		Domains: []string{"d-trust.net"},
+		Aliases: []string{"d-trust", "dtrust"},
+	},
+}
+
+// issuerRegistry indexes certificateAuthorities by issuer domain name and
+// alias.
+var issuerRegistry = func() map[string]int {
+	r := map[string]int{}
+	for i, ca := range certificateAuthorities {
+		for _, name := range append(ca.Domains[:len(ca.Domains):len(ca.Domains)], ca.Aliases...) {
+			r[name] = i
+		}
+	}
+	return r
+}()
+
+// CertificateAuthorities returns the well-known CAs of the issuer registry.
+func CertificateAuthorities() []CertificateAuthority {
+	r := make([]CertificateAuthority, len(certificateAuthorities))
+	copy(r, certificateAuthorities)
+	return r
+}
+
+// LookupIssuer looks up an issuer domain name or alias in the issuer
+// registry, returning the CA and the issuer domain name to use in records.
+func LookupIssuer(name string) (CertificateAuthority, string, bool) {
+	name = normalizeHostname(name)
+	i, ok := issuerRegistry[name]
+	if !ok {
+		return CertificateAuthority{}, "", false
+	}
+
+	ca := certificateAuthorities[i]
+	for _, domain := range ca.Domains {
+		if domain == name {
+			return ca, domain, true
+		}
+	}
+	return ca, ca.Domains[0], true
+}
@@ -0,0 +1,82 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package caabuilder_test
+
+import (
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+func TestLookupIssuer(t *testing.T) {
+	tests := []struct {
+		name       string
+		issuer     string
+		wantCA     string
+		wantDomain string
+		wantOK     bool
+	}{
+		{
+			name:       "Issuer Domain",
+			issuer:     "letsencrypt.org",
+			wantCA:     "Let's Encrypt",
+			wantDomain: "letsencrypt.org",
+			wantOK:     true,
+		},
+		{
+			name:       "Secondary Issuer Domain",
+			issuer:     "AmazonTrust.com.",
+			wantCA:     "Amazon",
+			wantDomain: "amazontrust.com",
+			wantOK:     true,
+		},
+		{
+			name:       "Alias",
+			issuer:     "Lets-Encrypt",
+			wantCA:     "Let's Encrypt",
+			wantDomain: "letsencrypt.org",
+			wantOK:     true,
+		},
+		{
+			name:       "Alias Without Domain",
+			issuer:     "amazon",
+			wantCA:     "Amazon",
+			wantDomain: "amazon.com",
+			wantOK:     true,
+		},
+		{
+			name:   "Unknown",
+			issuer: "ca.example.net",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca, domain, ok := caabuilder.LookupIssuer(tt.issuer)
+			if ok != tt.wantOK || ca.Name != tt.wantCA || domain != tt.wantDomain {
+				t.Errorf("LookupIssuer() = %q, %q, %v, want %q, %q, %v", ca.Name, domain, ok, tt.wantCA, tt.wantDomain, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCertificateAuthorities(t *testing.T) {
+	seen := map[string]string{}
+	for _, ca := range caabuilder.CertificateAuthorities() {
+		if len(ca.Domains) == 0 {
+			t.Errorf("%s has no issuer domain names", ca.Name)
+		}
+		for _, name := range append(ca.Domains, ca.Aliases...) {
+			if other, ok := seen[name]; ok {
+				t.Errorf("%q is listed for both %s and %s", name, other, ca.Name)
+			}
+			seen[name] = ca.Name
+		}
+		for _, domain := range ca.Domains {
+			if _, err := caabuilder.ParseIssuer(domain); err != nil {
+				t.Errorf("%s issuer domain name %q is invalid: %v", ca.Name, domain, err)
+			}
+		}
+	}
+}
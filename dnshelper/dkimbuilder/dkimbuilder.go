@@ -0,0 +1,154 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+// TXTStringSize is the maximum length of a single character-string in a TXT
+// record.
+const TXTStringSize = 255
+
+type DKIMConfig struct {
+	// Version is the v= tag, "DKIM1" when empty.
+	Version string
+	// KeyType is the k= tag, "rsa" or "ed25519". When empty it is taken from
+	// PublicKey.
+	KeyType string
+	// PublicKey is the PEM or base64 public key published in the p= tag. An
+	// empty key publishes a revoked selector.
+	PublicKey string
+	// HashAlgorithms is the h= list of acceptable hash algorithms.
+	HashAlgorithms []string
+	// ServiceTypes is the s= list of service types, "*" or "email".
+	ServiceTypes []string
+	// Flags is the t= list of flags: "y" for testing mode and "s" to forbid
+	// subdomains in the i= signature tag.
+	Flags []string
+	// Notes is the n= tag, notes for administrators.
+	Notes string
+}
+
+var validKeyTypes = map[string]bool{"rsa": true, "ed25519": true}
+
+var validServiceTypes = map[string]bool{"*": true, "email": true}
+
+var validFlags = map[string]bool{"y": true, "s": true}
+
+func DKIMBuilder(value DKIMConfig) (string, error) {
+	if value.Version == "" {
+		value.Version = "DKIM1"
+	}
+	if value.Version != "DKIM1" {
+		return "", fielderror.Errorf("Version", "invalid DKIM version %q, must be DKIM1", value.Version)
+	}
+
+	if value.KeyType != "" && !validKeyTypes[value.KeyType] {
+		return "", fielderror.Errorf("KeyType", "invalid DKIM key type %q, must be rsa or ed25519", value.KeyType)
+	}
+
+	var key string
+	if strings.TrimSpace(value.PublicKey) != "" {
+		k, err := ParsePublicKey(value.PublicKey)
+		if err != nil {
+			return "", fielderror.Wrap("PublicKey", err)
+		}
+		if value.KeyType != "" && value.KeyType != k.Type {
+			return "", fielderror.Errorf("KeyType", "key type %s does not match the %s public key", value.KeyType, k.Type)
+		}
+		value.KeyType = k.Type
+		key = k.String()
+	}
+
+	record := []string{"v=" + value.Version}
+
+	if value.KeyType != "" {
+		record = append(record, "k="+value.KeyType)
+	}
+
+	for i, h := range value.HashAlgorithms {
+		switch h {
+		case "sha256":
+		case "sha1":
+			return "", fielderror.New(fielderror.Index("HashAlgorithms", i), "sha1 must not be used for DKIM signatures (RFC 8301)")
+		default:
+			return "", fielderror.Errorf(fielderror.Index("HashAlgorithms", i), "invalid DKIM hash algorithm %q, must be sha256", h)
+		}
+	}
+	if len(value.HashAlgorithms) > 0 {
+		record = append(record, "h="+strings.Join(value.HashAlgorithms, ":"))
+	}
+
+	for i, s := range value.ServiceTypes {
+		if !validServiceTypes[s] {
+			return "", fielderror.Errorf(fielderror.Index("ServiceTypes", i), "invalid DKIM service type %q, must be * or email", s)
+		}
+	}
+	if len(value.ServiceTypes) > 0 {
+		record = append(record, "s="+strings.Join(value.ServiceTypes, ":"))
+	}
+
+	for i, f := range value.Flags {
+		if !validFlags[f] {
+			return "", fielderror.Errorf(fielderror.Index("Flags", i), "invalid DKIM flag %q, must be y or s", f)
+		}
+	}
+	if len(value.Flags) > 0 {
+		record = append(record, "t="+strings.Join(value.Flags, ":"))
+	}
+
+	if value.Notes != "" {
+		record = append(record, "n="+quotedPrintable(value.Notes))
+	}
+
+	record = append(record, "p="+key)
+
+	return strings.Join(record, "; "), nil
+}
+
+// RecordName returns the name of the DKIM key record of selector,
+// <selector>._domainkey, followed by domain unless it is empty.
+func RecordName(selector string, domain string) (string, error) {
+	selector = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(selector), "."))
+	if !validate.Hostname(selector) {
+		return "", fielderror.Errorf("Selector", "invalid DKIM selector %q, must be dot separated labels of letters, digits and hyphens", selector)
+	}
+
+	name, err := validate.RecordName(selector+"._domainkey", domain)
+	if err != nil {
+		return "", fielderror.Wrap("Domain", err)
+	}
+	return name, nil
+}
+
+// SplitTXT splits value into character-strings of at most size bytes, as
+// needed for TXT records longer than 255 bytes.
+func SplitTXT(value string, size int) []string {
+	r := []string{}
+	for len(value) > size {
+		r = append(r, value[:size])
+		value = value[size:]
+	}
+	return append(r, value)
+}
+
+// quotedPrintable encodes s as DKIM-Quoted-Printable (RFC 6376 section 2.11),
+// escaping whitespace, semicolons, equal signs and non-ASCII bytes.
+func quotedPrintable(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c > ' ' && c < 0x7f && c != ';' && c != '=' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "=%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PublicKey is a DKIM public key in the form published in the p= tag.
+type PublicKey struct {
+	// Type is the k= key type, "rsa" or "ed25519".
+	Type string
+	// Data is the p= key data: a DER SubjectPublicKeyInfo for RSA keys and the
+	// raw 32 byte key for Ed25519 keys (RFC 8463).
+	Data []byte
+	// Bits is the key size in bits.
+	Bits int
+}
+
+// String returns the key data base64 encoded, as published in the p= tag.
+func (k PublicKey) String() string {
+	return base64.StdEncoding.EncodeToString(k.Data)
+}
+
+// ParsePublicKey parses an RSA or Ed25519 public key in PEM or base64 DER
+// form, or a raw Ed25519 p= value. Private keys are rejected.
+func ParsePublicKey(key string) (PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return PublicKey{}, errors.New("public key must not be empty")
+	}
+
+	if strings.HasPrefix(key, "-----BEGIN") {
+		block, rest := pem.Decode([]byte(key))
+		if block == nil {
+			return PublicKey{}, errors.New("invalid PEM public key")
+		}
+		if strings.TrimSpace(string(rest)) != "" {
+			return PublicKey{}, errors.New("PEM public key must contain a single block")
+		}
+		switch block.Type {
+		case "PUBLIC KEY":
+			return parsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			return parsePKCS1PublicKey(block.Bytes)
+		}
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			return PublicKey{}, fmt.Errorf("PEM block is a %s, the public key must be used instead", block.Type)
+		}
+		return PublicKey{}, fmt.Errorf("unsupported PEM block type %q, must be PUBLIC KEY or RSA PUBLIC KEY", block.Type)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key), ""))
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("public key must be PEM or base64: %w", err)
+	}
+	if len(data) == ed25519.PublicKeySize {
+		return PublicKey{Type: "ed25519", Data: data, Bits: 256}, nil
+	}
+	if k, err := parsePKIXPublicKey(data); err == nil {
+		return k, nil
+	}
+	if k, err := parsePKCS1PublicKey(data); err == nil {
+		return k, nil
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(data); err == nil {
+		return PublicKey{}, errors.New("key is a private key, the public key must be used instead")
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(data); err == nil {
+		return PublicKey{}, errors.New("key is a private key, the public key must be used instead")
+	}
+	return PublicKey{}, errors.New("public key is neither an RSA nor an Ed25519 public key")
+}
+
+func parsePKIXPublicKey(der []byte) (PublicKey, error) {
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return PublicKey{Type: "rsa", Data: der, Bits: k.N.BitLen()}, nil
+	case ed25519.PublicKey:
+		return PublicKey{Type: "ed25519", Data: []byte(k), Bits: 256}, nil
+	default:
+		return PublicKey{}, fmt.Errorf("unsupported public key type %T, must be RSA or Ed25519", pub)
+	}
+}
+
+func parsePKCS1PublicKey(der []byte) (PublicKey, error) {
+	k, err := x509.ParsePKCS1PublicKey(der)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("invalid RSA public key: %w", err)
+	}
+	// Publish the SubjectPublicKeyInfo form used by virtually all signers.
+	data, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKey{Type: "rsa", Data: data, Bits: k.N.BitLen()}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// KeyPair is a generated DKIM signing key pair.
+type KeyPair struct {
+	// PrivateKeyPEM is the PKCS #8 encoded private key.
+	PrivateKeyPEM string
+	// PublicKeyPEM is the PKIX encoded public key.
+	PublicKeyPEM string
+	// PublicKey is the public key in the form published in the p= tag.
+	PublicKey PublicKey
+}
+
+var validRSABits = map[int]bool{2048: true, 4096: true}
+
+// GenerateKeyPair generates an RSA or Ed25519 DKIM key pair. bits is the RSA
+// key size, 2048 or 4096, with 0 meaning 2048, and must be 0 for Ed25519 keys.
+func GenerateKeyPair(keyType string, bits int) (KeyPair, error) {
+	var private crypto.Signer
+	switch keyType {
+	case "rsa":
+		if bits == 0 {
+			bits = RecommendedRSABits
+		}
+		if !validRSABits[bits] {
+			return KeyPair{}, fielderror.Errorf("Bits", "invalid RSA key size %d, must be 2048 or 4096", bits)
+		}
+		k, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return KeyPair{}, err
+		}
+		private = k
+	case "ed25519":
+		if bits != 0 {
+			return KeyPair{}, fielderror.New("Bits", "key size must not be set for ed25519 keys")
+		}
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return KeyPair{}, err
+		}
+		private = k
+	default:
+		return KeyPair{}, fielderror.Errorf("KeyType", "invalid DKIM key type %q, must be rsa or ed25519", keyType)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return KeyPair{}, err
+	}
+	public, err := parsePKIXPublicKey(publicDER)
+	if err != nil {
+		return KeyPair{}, err
+	}
+
+	return KeyPair{
+		PrivateKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		PublicKeyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		PublicKey:     public,
+	}, nil
+}
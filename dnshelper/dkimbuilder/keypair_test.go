@@ -0,0 +1,60 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyType  string
+		bits     int
+		wantBits int
+		wantErr  bool
+	}{
+		{name: "RSA Default", keyType: "rsa", wantBits: 2048},
+		{name: "RSA 4096", keyType: "rsa", bits: 4096, wantBits: 4096},
+		{name: "Ed25519", keyType: "ed25519", wantBits: 256},
+		{name: "RSA 1024", keyType: "rsa", bits: 1024, wantErr: true},
+		{name: "Ed25519 Bits", keyType: "ed25519", bits: 2048, wantErr: true},
+		{name: "Invalid Key Type", keyType: "ecdsa", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dkimbuilder.GenerateKeyPair(tt.keyType, tt.bits)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateKeyPair() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.PublicKey.Type != tt.keyType || got.PublicKey.Bits != tt.wantBits {
+				t.Errorf("GenerateKeyPair() = %s, %d, want %s, %d", got.PublicKey.Type, got.PublicKey.Bits, tt.keyType, tt.wantBits)
+			}
+
+			block, _ := pem.Decode([]byte(got.PrivateKeyPEM))
+			if block == nil || block.Type != "PRIVATE KEY" {
+				t.Fatalf("GenerateKeyPair() private key is not a PKCS #8 PEM block")
+			}
+			if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+				t.Errorf("GenerateKeyPair() private key: %v", err)
+			}
+
+			public, err := dkimbuilder.ParsePublicKey(got.PublicKeyPEM)
+			if err != nil {
+				t.Fatalf("GenerateKeyPair() public key: %v", err)
+			}
+			if public.String() != got.PublicKey.String() {
+				t.Errorf("GenerateKeyPair() public key = %s, want %s", public, got.PublicKey)
+			}
+		})
+	}
+}
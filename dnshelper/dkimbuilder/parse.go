@@ -0,0 +1,156 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MinRSABits is the smallest RSA key size verifiers must accept (RFC 8301).
+	// Verifiers reject smaller keys.
+	MinRSABits = 1024
+	// RecommendedRSABits is the RSA key size signers should use (RFC 8301).
+	RecommendedRSABits = 2048
+)
+
+// DKIMRecord is a parsed DKIM key record.
+type DKIMRecord struct {
+	// Config holds the published tags, with KeyType defaulting to "rsa" and
+	// Notes decoded.
+	Config DKIMConfig
+	// Key is the decoded public key, the zero value when the record is
+	// revoked.
+	Key PublicKey
+	// Revoked reports whether the record has an empty p= tag.
+	Revoked bool
+}
+
+// DKIMParse parses a DKIM key record and decodes its public key. Unknown tags
+// are ignored as required by RFC 6376.
+func DKIMParse(record string) (DKIMRecord, error) {
+	tags, err := parseTags(record)
+	if err != nil {
+		return DKIMRecord{}, err
+	}
+
+	if v, ok := tags["v"]; ok && v != "DKIM1" {
+		return DKIMRecord{}, fmt.Errorf("invalid DKIM version %q, must be DKIM1", v)
+	}
+
+	p, ok := tags["p"]
+	if !ok {
+		return DKIMRecord{}, errors.New("not a DKIM key record, the p= tag is required")
+	}
+
+	config := DKIMConfig{
+		Version:        tags["v"],
+		KeyType:        tags["k"],
+		PublicKey:      strings.Join(strings.Fields(p), ""),
+		HashAlgorithms: splitList(tags["h"]),
+		ServiceTypes:   splitList(tags["s"]),
+		Flags:          splitList(tags["t"]),
+	}
+	if config.KeyType == "" {
+		config.KeyType = "rsa"
+	}
+	if !validKeyTypes[config.KeyType] {
+		return DKIMRecord{}, fmt.Errorf("unsupported DKIM key type %q, must be rsa or ed25519", config.KeyType)
+	}
+	if config.Notes, err = decodeQuotedPrintable(tags["n"]); err != nil {
+		return DKIMRecord{}, err
+	}
+
+	r := DKIMRecord{Config: config, Revoked: config.PublicKey == ""}
+	if r.Revoked {
+		return r, nil
+	}
+
+	if r.Key, err = ParsePublicKey(config.PublicKey); err != nil {
+		return DKIMRecord{}, fmt.Errorf("invalid p= public key: %w", err)
+	}
+	if r.Key.Type != config.KeyType {
+		return DKIMRecord{}, fmt.Errorf("p= is an %s public key but the key type is %s", r.Key.Type, config.KeyType)
+	}
+
+	return r, nil
+}
+
+// CheckKeyStrength returns a warning for RSA keys smaller than
+// RecommendedRSABits, or an empty string. Ed25519 keys always pass.
+func CheckKeyStrength(key PublicKey) string {
+	if key.Type != "rsa" {
+		return ""
+	}
+	if key.Bits < MinRSABits {
+		return fmt.Sprintf("RSA key is %d bits, verifiers reject keys under %d bits (RFC 8301)", key.Bits, MinRSABits)
+	}
+	if key.Bits < RecommendedRSABits {
+		return fmt.Sprintf("RSA key is %d bits, keys of at least %d bits should be used (RFC 8301)", key.Bits, RecommendedRSABits)
+	}
+	return ""
+}
+
+// parseTags splits a DKIM tag-list into its tag-value pairs. When present, v=
+// must be the first tag.
+func parseTags(record string) (map[string]string, error) {
+	tags := map[string]string{}
+	for i, part := range strings.Split(record, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, found := strings.Cut(part, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !found || k == "" {
+			return nil, fmt.Errorf("invalid DKIM tag %q, must be tag=value", part)
+		}
+		if _, ok := tags[k]; ok {
+			return nil, fmt.Errorf("duplicate DKIM tag %q", k)
+		}
+		if k == "v" && i > 0 {
+			return nil, errors.New("the v= tag must be the first tag of a DKIM record")
+		}
+		tags[k] = v
+	}
+	return tags, nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var r []string
+	for _, item := range strings.Split(value, ":") {
+		if item = strings.TrimSpace(item); item != "" {
+			r = append(r, item)
+		}
+	}
+	return r
+}
+
+// decodeQuotedPrintable decodes a DKIM-Quoted-Printable value, the inverse of
+// quotedPrintable.
+func decodeQuotedPrintable(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '=' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("invalid quoted-printable n= notes %q", s)
+		}
+		c, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted-printable n= notes %q", s)
+		}
+		b.WriteByte(byte(c))
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,124 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+)
+
+func TestDKIMParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		record      string
+		want        dkimbuilder.DKIMConfig
+		wantBits    int
+		wantRevoked bool
+		wantErr     bool
+	}{
+		{
+			name:     "RSA",
+			record:   "v=DKIM1; k=rsa; p=" + rsa2048,
+			want:     dkimbuilder.DKIMConfig{Version: "DKIM1", KeyType: "rsa", PublicKey: rsa2048},
+			wantBits: 2048,
+		},
+		{
+			name:     "Default Key Type",
+			record:   "p=" + rsa1024,
+			want:     dkimbuilder.DKIMConfig{KeyType: "rsa", PublicKey: rsa1024},
+			wantBits: 1024,
+		},
+		{
+			name:     "Split Key",
+			record:   "v=DKIM1;k=rsa;p=" + rsa2048[:200] + " " + rsa2048[200:],
+			want:     dkimbuilder.DKIMConfig{Version: "DKIM1", KeyType: "rsa", PublicKey: rsa2048},
+			wantBits: 2048,
+		},
+		{
+			name:     "All Tags",
+			record:   "v=DKIM1; k=ed25519; h=sha256; s=email; t=y:s; n=rotated=202026-01=3B=20owner=3Dmail; x=ignored; p=" + ed25519Raw,
+			want:     dkimbuilder.DKIMConfig{Version: "DKIM1", KeyType: "ed25519", PublicKey: ed25519Raw, HashAlgorithms: []string{"sha256"}, ServiceTypes: []string{"email"}, Flags: []string{"y", "s"}, Notes: "rotated 2026-01; owner=mail"},
+			wantBits: 256,
+		},
+		{
+			name:        "Revoked",
+			record:      "v=DKIM1; p=",
+			want:        dkimbuilder.DKIMConfig{Version: "DKIM1", KeyType: "rsa"},
+			wantRevoked: true,
+		},
+		{
+			name:    "Missing Key",
+			record:  "v=DKIM1; k=rsa",
+			wantErr: true,
+		},
+		{
+			name:    "Version Not First",
+			record:  "k=rsa; v=DKIM1; p=" + rsa2048,
+			wantErr: true,
+		},
+		{
+			name:    "Duplicate Tag",
+			record:  "v=DKIM1; p=; p=" + rsa2048,
+			wantErr: true,
+		},
+		{
+			name:    "Key Type Mismatch",
+			record:  "v=DKIM1; p=" + ed25519Raw,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Key",
+			record:  "v=DKIM1; p=" + rsa2048[:100],
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Notes",
+			record:  "v=DKIM1; n=bad=Z; p=",
+			wantErr: true,
+		},
+		{
+			name:    "DMARC Record",
+			record:  "v=DMARC1; p=reject",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dkimbuilder.DKIMParse(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DKIMParse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Config, tt.want) || got.Key.Bits != tt.wantBits || got.Revoked != tt.wantRevoked {
+				t.Errorf("DKIMParse() = %+v, %d, %v, want %+v, %d, %v", got.Config, got.Key.Bits, got.Revoked, tt.want, tt.wantBits, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestCheckKeyStrength(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         dkimbuilder.PublicKey
+		wantWarning string
+	}{
+		{name: "RSA 2048", key: dkimbuilder.PublicKey{Type: "rsa", Bits: 2048}},
+		{name: "RSA 1024", key: dkimbuilder.PublicKey{Type: "rsa", Bits: 1024}, wantWarning: "RSA key is 1024 bits, keys of at least 2048 bits should be used (RFC 8301)"},
+		{name: "RSA 512", key: dkimbuilder.PublicKey{Type: "rsa", Bits: 512}, wantWarning: "RSA key is 512 bits, verifiers reject keys under 1024 bits (RFC 8301)"},
+		{name: "Ed25519", key: dkimbuilder.PublicKey{Type: "ed25519", Bits: 256}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if warning := dkimbuilder.CheckKeyStrength(tt.key); warning != tt.wantWarning {
+				t.Errorf("CheckKeyStrength() = %q, want %q", warning, tt.wantWarning)
+			}
+		})
+	}
+}
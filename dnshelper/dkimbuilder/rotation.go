@@ -0,0 +1,185 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+// Rotation phases, in the order a key rotation goes through them.
+const (
+	// PhasePrepublish publishes the current key next to the previous one,
+	// which still signs, so the new record propagates before it is used.
+	PhasePrepublish = "prepublish"
+	// PhaseActive signs with the current key while the previous one stays
+	// published for messages still in transit.
+	PhaseActive = "active"
+	// PhaseRevoke replaces the previous key with a revocation record (p=).
+	PhaseRevoke = "revoke"
+	// PhaseComplete only publishes the current key.
+	PhaseComplete = "complete"
+)
+
+var validPhases = map[string]bool{PhasePrepublish: true, PhaseActive: true, PhaseRevoke: true, PhaseComplete: true}
+
+type RotationConfig struct {
+	// SelectorFormat names the selector of a key from its date, with the
+	// strftime-like verbs %Y, %y, %m, %d and %%, e.g. "s%Y%m".
+	SelectorFormat string
+	// CurrentDate and PreviousDate are the dates of the current and previous
+	// keys. PreviousDate is not used in PhaseComplete.
+	CurrentDate  time.Time
+	PreviousDate time.Time
+	// CurrentKey and PreviousKey are the PEM or base64 public keys.
+	// PreviousKey is only needed in PhasePrepublish and PhaseActive.
+	CurrentKey  string
+	PreviousKey string
+	Phase       string
+	// Domain is appended to the record names when set.
+	Domain string
+	// Template holds the tags, other than the key, shared by the records of
+	// both keys. KeyType and PublicKey must not be set.
+	Template DKIMConfig
+}
+
+// RotationRecord is a DKIM record to publish during a rotation.
+type RotationRecord struct {
+	Selector string
+	Name     string
+	Value    string
+	Revoked  bool
+}
+
+// Rotation returns the DKIM records to publish in the given rotation phase,
+// current key first, along with the selector messages must be signed with.
+func Rotation(config RotationConfig) ([]RotationRecord, string, error) {
+	if !validPhases[config.Phase] {
+		return nil, "", fielderror.Errorf("Phase", "invalid DKIM rotation phase %q, must be one of %s, %s, %s or %s", config.Phase, PhasePrepublish, PhaseActive, PhaseRevoke, PhaseComplete)
+	}
+	if config.Template.KeyType != "" {
+		return nil, "", fielderror.New("KeyType", "key type is taken from the rotated keys and must not be set")
+	}
+	if config.Template.PublicKey != "" {
+		return nil, "", fielderror.New("PublicKey", "public key is set by the rotation and must not be set")
+	}
+
+	if config.CurrentDate.IsZero() {
+		return nil, "", fielderror.New("CurrentDate", "current key date must be set")
+	}
+	current, err := FormatSelector(config.SelectorFormat, config.CurrentDate)
+	if err != nil {
+		return nil, "", err
+	}
+	if strings.TrimSpace(config.CurrentKey) == "" {
+		return nil, "", fielderror.New("CurrentKey", "current key must not be empty")
+	}
+	currentRecord, err := rotationRecord(config, current, "CurrentKey", config.CurrentKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	records := []RotationRecord{currentRecord}
+	if config.Phase == PhaseComplete {
+		return records, current, nil
+	}
+
+	if config.PreviousDate.IsZero() {
+		return nil, "", fielderror.Errorf("PreviousDate", "previous key date must be set in the %s phase", config.Phase)
+	}
+	previous, err := FormatSelector(config.SelectorFormat, config.PreviousDate)
+	if err != nil {
+		return nil, "", err
+	}
+	if previous == current {
+		return nil, "", fielderror.Errorf("PreviousDate", "the previous and current keys both use selector %q", current)
+	}
+
+	previousKey := ""
+	if config.Phase != PhaseRevoke {
+		if strings.TrimSpace(config.PreviousKey) == "" {
+			return nil, "", fielderror.Errorf("PreviousKey", "previous key must not be empty in the %s phase", config.Phase)
+		}
+		previousKey = config.PreviousKey
+	}
+	previousRecord, err := rotationRecord(config, previous, "PreviousKey", previousKey)
+	if err != nil {
+		return nil, "", err
+	}
+	records = append(records, previousRecord)
+
+	if config.Phase == PhasePrepublish {
+		return records, previous, nil
+	}
+	return records, current, nil
+}
+
+func rotationRecord(config RotationConfig, selector string, field string, key string) (RotationRecord, error) {
+	name, err := RecordName(selector, config.Domain)
+	if err != nil {
+		return RotationRecord{}, err
+	}
+
+	c := config.Template
+	c.PublicKey = key
+	if key == "" {
+		// A revocation record only needs the empty key.
+		c = DKIMConfig{Version: c.Version}
+	}
+	value, err := DKIMBuilder(c)
+	if err != nil {
+		var fe *fielderror.Error
+		if errors.As(err, &fe) && fe.Field == "PublicKey" {
+			return RotationRecord{}, fielderror.Wrap(field, fe.Err)
+		}
+		return RotationRecord{}, err
+	}
+
+	return RotationRecord{Selector: selector, Name: name, Value: value, Revoked: key == ""}, nil
+}
+
+// FormatSelector formats the selector of a key dated date, replacing the
+// strftime-like verbs %Y, %y, %m, %d and %% of format.
+func FormatSelector(format string, date time.Time) (string, error) {
+	if format == "" {
+		return "", fielderror.New("SelectorFormat", "selector format must not be empty")
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		if i+1 == len(format) {
+			return "", fielderror.New("SelectorFormat", "selector format must not end with %")
+		}
+		i++
+		switch format[i] {
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", date.Year())
+		case 'y':
+			fmt.Fprintf(&b, "%02d", date.Year()%100)
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(date.Month()))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", date.Day())
+		case '%':
+			b.WriteByte('%')
+		default:
+			return "", fielderror.Errorf("SelectorFormat", "unsupported selector format verb %%%c, must be one of %%Y, %%y, %%m, %%d or %%%%", format[i])
+		}
+	}
+
+	selector := strings.ToLower(b.String())
+	if !validate.Hostname(selector) {
+		return "", fielderror.Errorf("SelectorFormat", "selector format produces invalid selector %q", selector)
+	}
+	return selector, nil
+}
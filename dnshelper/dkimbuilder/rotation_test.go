@@ -0,0 +1,182 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dkimbuilder_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+func TestFormatSelector(t *testing.T) {
+	date := time.Date(2026, time.March, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{name: "Year Month", format: "s%Y%m", want: "s202603"},
+		{name: "All Verbs", format: "Mail-%y%m%d", want: "mail-260307"},
+		{name: "Labels", format: "%Y.%m.k", want: "2026.03.k"},
+		{name: "Literal", format: "static", want: "static"},
+		{name: "Empty", format: "", wantErr: true},
+		{name: "Unknown Verb", format: "s%H", wantErr: true},
+		{name: "Trailing Percent", format: "s%", wantErr: true},
+		{name: "Invalid Selector", format: "s_%Y", wantErr: true},
+		{name: "Escaped Percent", format: "s%%", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dkimbuilder.FormatSelector(tt.format, date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatSelector() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	base := dkimbuilder.RotationConfig{
+		SelectorFormat: "s%Y%m",
+		CurrentDate:    time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+		PreviousDate:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CurrentKey:     ed25519PEM,
+		PreviousKey:    rsa2048,
+		Domain:         "example.com",
+	}
+	with := func(f func(c *dkimbuilder.RotationConfig)) dkimbuilder.RotationConfig {
+		c := base
+		f(&c)
+		return c
+	}
+
+	current := dkimbuilder.RotationRecord{Selector: "s202607", Name: "s202607._domainkey.example.com", Value: "v=DKIM1; k=ed25519; p=" + ed25519Raw}
+	previous := dkimbuilder.RotationRecord{Selector: "s202601", Name: "s202601._domainkey.example.com", Value: "v=DKIM1; k=rsa; p=" + rsa2048}
+	revoked := dkimbuilder.RotationRecord{Selector: "s202601", Name: "s202601._domainkey.example.com", Value: "v=DKIM1; p=", Revoked: true}
+
+	tests := []struct {
+		name        string
+		config      dkimbuilder.RotationConfig
+		want        []dkimbuilder.RotationRecord
+		wantSigning string
+		wantField   string
+	}{
+		{
+			name:        "Prepublish",
+			config:      with(func(c *dkimbuilder.RotationConfig) { c.Phase = dkimbuilder.PhasePrepublish }),
+			want:        []dkimbuilder.RotationRecord{current, previous},
+			wantSigning: "s202601",
+		},
+		{
+			name:        "Active",
+			config:      with(func(c *dkimbuilder.RotationConfig) { c.Phase = dkimbuilder.PhaseActive }),
+			want:        []dkimbuilder.RotationRecord{current, previous},
+			wantSigning: "s202607",
+		},
+		{
+			name: "Revoke",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseRevoke
+				c.PreviousKey = ""
+			}),
+			want:        []dkimbuilder.RotationRecord{current, revoked},
+			wantSigning: "s202607",
+		},
+		{
+			name: "Complete",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseComplete
+				c.PreviousDate = time.Time{}
+			}),
+			want:        []dkimbuilder.RotationRecord{current},
+			wantSigning: "s202607",
+		},
+		{
+			name: "Template",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseRevoke
+				c.Template = dkimbuilder.DKIMConfig{ServiceTypes: []string{"email"}}
+			}),
+			want: []dkimbuilder.RotationRecord{
+				{Selector: "s202607", Name: "s202607._domainkey.example.com", Value: "v=DKIM1; k=ed25519; s=email; p=" + ed25519Raw},
+				revoked,
+			},
+			wantSigning: "s202607",
+		},
+		{
+			name:      "Invalid Phase",
+			config:    with(func(c *dkimbuilder.RotationConfig) { c.Phase = "retire" }),
+			wantField: "Phase",
+		},
+		{
+			name: "Same Selector",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseActive
+				c.PreviousDate = time.Date(2026, time.July, 15, 0, 0, 0, 0, time.UTC)
+			}),
+			wantField: "PreviousDate",
+		},
+		{
+			name: "Missing Previous Key",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhasePrepublish
+				c.PreviousKey = ""
+			}),
+			wantField: "PreviousKey",
+		},
+		{
+			name: "Invalid Previous Key",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseActive
+				c.PreviousKey = "AAAA"
+			}),
+			wantField: "PreviousKey",
+		},
+		{
+			name: "Missing Current Date",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseComplete
+				c.CurrentDate = time.Time{}
+			}),
+			wantField: "CurrentDate",
+		},
+		{
+			name: "Key Type In Template",
+			config: with(func(c *dkimbuilder.RotationConfig) {
+				c.Phase = dkimbuilder.PhaseComplete
+				c.Template = dkimbuilder.DKIMConfig{KeyType: "rsa"}
+			}),
+			wantField: "KeyType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, signing, err := dkimbuilder.Rotation(tt.config)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("Rotation() error = %v, want field %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Rotation() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || signing != tt.wantSigning {
+				t.Errorf("Rotation() = %+v, %q, want %+v, %q", got, signing, tt.want, tt.wantSigning)
+			}
+		})
+	}
+}
@@ -4,23 +4,31 @@
 package dmarcbuilder
 
 import (
-	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
 )
 
 type DMARCConfig struct {
-	Version         string
-	Policy          string
-	SubdomainPolicy string
-	AlignmentSPF    string
-	AlignmentDKIM   string
-	Percent         int32
-	RUA             []string
-	RUF             []string
-	FailureOptions  string
-	FailureFormat   string
-	ReportInterval  int32
+	Version           string
+	Policy            string
+	SubdomainPolicy   string
+	NonexistentPolicy string
+	AlignmentSPF      string
+	AlignmentDKIM     string
+	Percent           int32
+	// PercentSet distinguishes an explicit pct=0 from an absent pct= tag.
+	// DmarcParse sets it when the record has a pct= tag, and DmarcBuilder
+	// emits pct= whenever it is set.
+	PercentSet     bool
+	Testing        bool
+	RUA            []string
+	RUF            []string
+	FailureOptions string
+	FailureFormat  string
+	ReportInterval int32
 }
 
 func DmarcBuilder(value DMARCConfig) (string, error) {
@@ -32,41 +40,61 @@ func DmarcBuilder(value DMARCConfig) (string, error) {
 		value.Policy = "none"
 	}
 
-	validPolicies := map[string]bool{"none": true, "quarantine": true, "reject": true}
 	if !validPolicies[value.Policy] {
-		return "", errors.New("invalid DMARC policy")
+		return "", fielderror.New("Policy", "invalid DMARC policy")
 	}
 
 	record := []string{"v=" + value.Version, "p=" + value.Policy}
 
 	if value.SubdomainPolicy != "" {
 		if !validPolicies[value.SubdomainPolicy] {
-			return "", errors.New("invalid DMARC subdomain policy")
+			return "", fielderror.New("SubdomainPolicy", "invalid DMARC subdomain policy")
 		}
 		record = append(record, "sp="+value.SubdomainPolicy)
 	}
 
+	if value.NonexistentPolicy != "" {
+		if !validPolicies[value.NonexistentPolicy] {
+			return "", fielderror.New("NonexistentPolicy", "invalid DMARC non-existent subdomain policy")
+		}
+		record = append(record, "np="+value.NonexistentPolicy)
+	}
+
 	alignments := map[string]string{"relaxed": "r", "strict": "s", "r": "r", "s": "s"}
 	if val, ok := alignments[value.AlignmentDKIM]; ok {
 		record = append(record, "adkim="+val)
 	} else if value.AlignmentDKIM != "" {
-		return "", errors.New("invalid DMARC DKIM alignment policy")
+		return "", fielderror.New("AlignmentDKIM", "invalid DMARC DKIM alignment policy")
 	}
 
 	if val, ok := alignments[value.AlignmentSPF]; ok {
 		record = append(record, "aspf="+val)
 	} else if value.AlignmentSPF != "" {
-		return "", errors.New("invalid DMARC SPF alignment policy")
+		return "", fielderror.New("AlignmentSPF", "invalid DMARC SPF alignment policy")
 	}
 
-	if value.Percent > 0 {
+	if value.Percent > 0 || value.PercentSet {
 		record = append(record, fmt.Sprintf("pct=%d", value.Percent))
 	}
 
+	if value.Testing {
+		record = append(record, "t=y")
+	}
+
+	for i, uri := range value.RUA {
+		if err := validateReportURI(uri); err != nil {
+			return "", fielderror.Wrap(fielderror.Index("RUA", i), err)
+		}
+	}
 	if len(value.RUA) > 0 {
 		record = append(record, "rua="+strings.Join(value.RUA, ","))
 	}
 
+	for i, uri := range value.RUF {
+		if err := validateReportURI(uri); err != nil {
+			return "", fielderror.Wrap(fielderror.Index("RUF", i), err)
+		}
+	}
 	if len(value.RUF) > 0 {
 		record = append(record, "ruf="+strings.Join(value.RUF, ","))
 	}
@@ -76,7 +104,7 @@ func DmarcBuilder(value DMARCConfig) (string, error) {
 		if validFailureOptions[value.FailureOptions] {
 			record = append(record, "fo="+value.FailureOptions)
 		} else {
-			return "", errors.New("invalid DMARC failure options")
+			return "", fielderror.New("FailureOptions", "invalid DMARC failure options")
 		}
 	}
 
@@ -90,3 +118,20 @@ func DmarcBuilder(value DMARCConfig) (string, error) {
 
 	return strings.Join(record, "; "), nil
 }
+
+// validateReportURI checks a rua= or ruf= destination is an absolute URI, with
+// an optional size limit such as "!10m" (RFC 7489 section 6.2). mailto: URIs
+// must carry a single email address.
+func validateReportURI(uri string) error {
+	if u, size, ok := strings.Cut(uri, "!"); ok {
+		digits := strings.TrimRight(size, "kmgt")
+		if digits == "" || len(size)-len(digits) > 1 || strings.Trim(digits, "0123456789") != "" {
+			return fmt.Errorf("invalid report size limit %q in %q, must be a number with an optional k, m, g or t unit", size, uri)
+		}
+		uri = u
+	}
+	if len(uri) >= 7 && strings.EqualFold(uri[:7], "mailto:") {
+		return validate.MailtoURI(uri)
+	}
+	return validate.AbsoluteURI(uri)
+}
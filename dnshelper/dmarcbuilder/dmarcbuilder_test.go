@@ -4,9 +4,11 @@
 package dmarcbuilder_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
 )
 
 func TestDmarcBuilder(t *testing.T) {
@@ -89,6 +91,15 @@ func TestDmarcBuilder(t *testing.T) {
 			want:    "v=DMARC1; p=none; pct=50",
 			wantErr: false,
 		},
+		{
+			name: "Explicit Zero Percentage",
+			args: dmarcbuilder.DMARCConfig{
+				Policy:     "reject",
+				PercentSet: true,
+			},
+			want:    "v=DMARC1; p=reject; pct=0",
+			wantErr: false,
+		},
 		{
 			name: "RUA Settings",
 			args: dmarcbuilder.DMARCConfig{
@@ -130,6 +141,22 @@ func TestDmarcBuilder(t *testing.T) {
 			want:    "v=DMARC1; p=none; rua=mailto:dmarc1@example.com,mailto:dmarc2@example.com; ruf=mailto:forensics1@example.com,mailto:forensics2@example.com",
 			wantErr: false,
 		},
+		{
+			name: "Report Size Limit",
+			args: dmarcbuilder.DMARCConfig{
+				RUA: []string{"mailto:dmarc@example.com!10m"},
+			},
+			want:    "v=DMARC1; p=none; rua=mailto:dmarc@example.com!10m",
+			wantErr: false,
+		},
+		{
+			name: "HTTPS Report URI",
+			args: dmarcbuilder.DMARCConfig{
+				RUA: []string{"mailto:dmarc@example.com", "https://reports.example.com/dmarc!50m"},
+			},
+			want:    "v=DMARC1; p=none; rua=mailto:dmarc@example.com,https://reports.example.com/dmarc!50m",
+			wantErr: false,
+		},
 		{
 			name: "Failure Options 1",
 			args: dmarcbuilder.DMARCConfig{
@@ -154,3 +181,73 @@ func TestDmarcBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestDmarcBuilder_FieldError(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      dmarcbuilder.DMARCConfig
+		wantField string
+	}{
+		{
+			name:      "Invalid Policy",
+			args:      dmarcbuilder.DMARCConfig{Policy: "invalid"},
+			wantField: "Policy",
+		},
+		{
+			name:      "Invalid Subdomain Policy",
+			args:      dmarcbuilder.DMARCConfig{SubdomainPolicy: "invalid"},
+			wantField: "SubdomainPolicy",
+		},
+		{
+			name:      "Invalid DKIM Alignment",
+			args:      dmarcbuilder.DMARCConfig{AlignmentDKIM: "invalid"},
+			wantField: "AlignmentDKIM",
+		},
+		{
+			name:      "Invalid SPF Alignment",
+			args:      dmarcbuilder.DMARCConfig{AlignmentSPF: "invalid"},
+			wantField: "AlignmentSPF",
+		},
+		{
+			name: "Invalid Failure Options",
+			args: dmarcbuilder.DMARCConfig{
+				RUF:            []string{"mailto:forensics@example.com"},
+				FailureOptions: "invalid",
+			},
+			wantField: "FailureOptions",
+		},
+		{
+			name:      "Invalid RUA Address",
+			args:      dmarcbuilder.DMARCConfig{RUA: []string{"mailto:dmarc@example.com", "mailto:example.com"}},
+			wantField: "RUA[1]",
+		},
+		{
+			name:      "Bare RUA Address",
+			args:      dmarcbuilder.DMARCConfig{RUA: []string{"dmarc@example.com"}},
+			wantField: "RUA[0]",
+		},
+		{
+			name:      "Joined RUF Addresses",
+			args:      dmarcbuilder.DMARCConfig{RUF: []string{"mailto:a@example.com,mailto:b@example.com"}},
+			wantField: "RUF[0]",
+		},
+		{
+			name:      "Invalid Report Size Limit",
+			args:      dmarcbuilder.DMARCConfig{RUA: []string{"mailto:dmarc@example.com!10x"}},
+			wantField: "RUA[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dmarcbuilder.DmarcBuilder(tt.args)
+			var fe *fielderror.Error
+			if !errors.As(err, &fe) {
+				t.Fatalf("DmarcBuilder() error = %v, want field error", err)
+			}
+			if fe.Field != tt.wantField {
+				t.Errorf("DmarcBuilder() error field = %v, want %v", fe.Field, tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dmarcbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// DmarcParse parses a DMARC record into a DMARCConfig. Absent tags are left
+// empty and alignment modes are returned as "r" or "s".
+func DmarcParse(record string) (DMARCConfig, error) {
+	tags, ok := parseTags(record)
+	if !ok {
+		return DMARCConfig{}, errors.New("not a DMARC record, must start with v=DMARC1")
+	}
+	config, err := parseConfig(tags)
+	if err == nil && config.Policy == "" {
+		err = fielderror.New("Policy", "invalid DMARC policy")
+	}
+	if err != nil {
+		return DMARCConfig{}, err
+	}
+	return config, nil
+}
+
+// parseConfig builds the DMARCConfig of the tags of a DMARC record. A missing
+// p= tag is left to the caller.
+func parseConfig(tags map[string]string) (DMARCConfig, error) {
+	config := DMARCConfig{
+		Version:           tags["v"],
+		Policy:            tags["p"],
+		SubdomainPolicy:   tags["sp"],
+		NonexistentPolicy: tags["np"],
+		AlignmentSPF:      tags["aspf"],
+		AlignmentDKIM:     tags["adkim"],
+		RUA:               splitURIs(tags["rua"]),
+		RUF:               splitURIs(tags["ruf"]),
+		FailureOptions:    tags["fo"],
+		FailureFormat:     tags["rf"],
+		Testing:           tags["t"] == "y",
+		PercentSet:        tags["pct"] != "",
+	}
+
+	if config.Policy != "" && !validPolicies[config.Policy] {
+		return DMARCConfig{}, fielderror.New("Policy", "invalid DMARC policy")
+	}
+	if config.SubdomainPolicy != "" && !validPolicies[config.SubdomainPolicy] {
+		return DMARCConfig{}, fielderror.New("SubdomainPolicy", "invalid DMARC subdomain policy")
+	}
+	if config.NonexistentPolicy != "" && !validPolicies[config.NonexistentPolicy] {
+		return DMARCConfig{}, fielderror.New("NonexistentPolicy", "invalid DMARC non-existent subdomain policy")
+	}
+	if config.AlignmentSPF != "" && config.AlignmentSPF != "r" && config.AlignmentSPF != "s" {
+		return DMARCConfig{}, fielderror.New("AlignmentSPF", "invalid DMARC SPF alignment policy")
+	}
+	if config.AlignmentDKIM != "" && config.AlignmentDKIM != "r" && config.AlignmentDKIM != "s" {
+		return DMARCConfig{}, fielderror.New("AlignmentDKIM", "invalid DMARC DKIM alignment policy")
+	}
+	if t := tags["t"]; t != "" && t != "y" && t != "n" {
+		return DMARCConfig{}, fielderror.New("Testing", "invalid DMARC testing mode, must be 'y' or 'n'")
+	}
+
+	var err error
+	if config.Percent, err = parseInt32(tags["pct"], 0, 100); err != nil {
+		return DMARCConfig{}, fielderror.Wrap("Percent", err)
+	}
+	if config.ReportInterval, err = parseInt32(tags["ri"], 0, 1<<31-1); err != nil {
+		return DMARCConfig{}, fielderror.Wrap("ReportInterval", err)
+	}
+
+	return config, nil
+}
+
+// parseTags splits a DMARC record into its tag-value pairs, reporting false
+// when the record does not start with v=DMARC1.
+func parseTags(record string) (map[string]string, bool) {
+	tags := map[string]string{}
+	first := true
+	for _, part := range strings.Split(record, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, found := strings.Cut(part, "=")
+		k, v = strings.ToLower(strings.TrimSpace(k)), strings.TrimSpace(v)
+		if first && (!found || k != "v" || v != "DMARC1") {
+			return nil, false
+		}
+		first = false
+		if !found {
+			continue
+		}
+		switch k {
+		case "p", "sp", "np", "adkim", "aspf", "t":
+			v = strings.ToLower(v)
+		}
+		tags[k] = v
+	}
+	return tags, len(tags) > 0
+}
+
+func splitURIs(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var r []string
+	for _, uri := range strings.Split(value, ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			r = append(r, uri)
+		}
+	}
+	return r
+}
+
+func parseInt32(value string, minimum int64, maximum int64) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || i < minimum || i > maximum {
+		return 0, fmt.Errorf("must be a whole number between %d and %d", minimum, maximum)
+	}
+	return int32(i), nil
+}
@@ -0,0 +1,149 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dmarcbuilder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+)
+
+func TestDmarcParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string
+		want    dmarcbuilder.DMARCConfig
+		wantErr bool
+	}{
+		{
+			name:   "Basic DMARC Record",
+			record: "v=DMARC1; p=none",
+			want: dmarcbuilder.DMARCConfig{
+				Version: "DMARC1",
+				Policy:  "none",
+			},
+		},
+		{
+			name:   "Complete DMARC Record",
+			record: "v=DMARC1; p=reject; sp=quarantine; np=reject; adkim=s; aspf=r; pct=50; rua=mailto:dmarc@example.com, mailto:dmarc@example.org; ruf=mailto:forensics@example.com; fo=1; rf=afrf; ri=86400",
+			want: dmarcbuilder.DMARCConfig{
+				Version:           "DMARC1",
+				Policy:            "reject",
+				SubdomainPolicy:   "quarantine",
+				NonexistentPolicy: "reject",
+				AlignmentSPF:      "r",
+				AlignmentDKIM:     "s",
+				Percent:           50,
+				PercentSet:        true,
+				RUA:               []string{"mailto:dmarc@example.com", "mailto:dmarc@example.org"},
+				RUF:               []string{"mailto:forensics@example.com"},
+				FailureOptions:    "1",
+				FailureFormat:     "afrf",
+				ReportInterval:    86400,
+			},
+		},
+		{
+			name:   "Case And Whitespace",
+			record: "v=DMARC1;P=Reject ;  SP = none;",
+			want: dmarcbuilder.DMARCConfig{
+				Version:         "DMARC1",
+				Policy:          "reject",
+				SubdomainPolicy: "none",
+			},
+		},
+		{
+			name:   "Testing Mode",
+			record: "v=DMARC1; p=quarantine; t=y",
+			want: dmarcbuilder.DMARCConfig{
+				Version: "DMARC1",
+				Policy:  "quarantine",
+				Testing: true,
+			},
+		},
+		{
+			name:   "Zero Percent",
+			record: "v=DMARC1; p=reject; pct=0",
+			want: dmarcbuilder.DMARCConfig{
+				Version:    "DMARC1",
+				Policy:     "reject",
+				PercentSet: true,
+			},
+		},
+		{
+			name:    "Not A DMARC Record",
+			record:  "v=spf1 -all",
+			wantErr: true,
+		},
+		{
+			name:    "Version Not First",
+			record:  "p=reject; v=DMARC1",
+			wantErr: true,
+		},
+		{
+			name:    "Version After Empty Tag",
+			record:  "; p=reject; v=DMARC1",
+			wantErr: true,
+		},
+		{
+			name:    "Missing Policy",
+			record:  "v=DMARC1; rua=mailto:dmarc@example.com",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Alignment",
+			record:  "v=DMARC1; p=none; adkim=x",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Percent",
+			record:  "v=DMARC1; p=none; pct=150",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dmarcbuilder.DmarcParse(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DmarcParse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DmarcParse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDmarcParse_RoundTrip(t *testing.T) {
+	config := dmarcbuilder.DMARCConfig{
+		Policy:            "quarantine",
+		SubdomainPolicy:   "reject",
+		NonexistentPolicy: "reject",
+		AlignmentDKIM:     "strict",
+		Percent:           25,
+		RUA:               []string{"mailto:dmarc@example.com"},
+	}
+
+	record, err := dmarcbuilder.DmarcBuilder(config)
+	if err != nil {
+		t.Fatalf("DmarcBuilder() error = %v", err)
+	}
+	if record != "v=DMARC1; p=quarantine; sp=reject; np=reject; adkim=s; pct=25; rua=mailto:dmarc@example.com" {
+		t.Errorf("DmarcBuilder() = %v", record)
+	}
+
+	parsed, err := dmarcbuilder.DmarcParse(record)
+	if err != nil {
+		t.Fatalf("DmarcParse() error = %v", err)
+	}
+	rebuilt, err := dmarcbuilder.DmarcBuilder(parsed)
+	if err != nil {
+		t.Fatalf("DmarcBuilder() error = %v", err)
+	}
+	if rebuilt != record {
+		t.Errorf("DmarcBuilder(DmarcParse()) = %v, want %v", rebuilt, record)
+	}
+}
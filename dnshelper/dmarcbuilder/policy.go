@@ -0,0 +1,236 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dmarcbuilder
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"golang.org/x/net/publicsuffix"
+)
+
+type Resolver interface {
+	GetTXT(domain string) ([]string, error)
+}
+
+// EffectivePolicy is the DMARC policy applied to mail from Hostname and the
+// record it was taken from, both empty when no record applies.
+type EffectivePolicy struct {
+	Hostname             string
+	OrganizationalDomain string
+	RecordName           string
+	Record               string
+	// PolicyTag is the tag the policy was read from: "p", "sp" or "np".
+	PolicyTag string
+	Policy    string
+}
+
+var validPolicies = map[string]bool{"none": true, "quarantine": true, "reject": true}
+
+// OrganizationalDomain returns the organizational domain of hostname (RFC
+// 7489 section 3.2) using the embedded Public Suffix List.
+func OrganizationalDomain(hostname string) (string, error) {
+	hostname = normalizeHostname(hostname)
+	if hostname == "" {
+		return "", fielderror.New("Hostname", "hostname must not be empty")
+	}
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(hostname)
+	if err != nil {
+		return "", fielderror.Wrap("Hostname", err)
+	}
+	return domain, nil
+}
+
+// Record is the DMARC record that applies to Domain.
+type Record struct {
+	Domain               string
+	OrganizationalDomain string
+	// Name is where the record was found, _dmarc.<Domain> or
+	// _dmarc.<OrganizationalDomain>. It is empty when neither publishes one.
+	Name   string
+	Value  string
+	Config DMARCConfig
+	// Policy is the policy applied to mail from Domain, taken from sp= when
+	// the record is inherited from the organizational domain.
+	Policy string
+	// Discarded explains why no record applies when the record published
+	// for Domain is discarded by receivers.
+	Discarded string
+}
+
+// LookupRecord returns the DMARC record for domain, falling back to the record
+// of its organizational domain as described in RFC 7489 section 6.6.3.
+func LookupRecord(domain string, resolver Resolver) (Record, error) {
+	d, err := discover(domain, resolver)
+	if err != nil {
+		return Record{}, err
+	}
+
+	r := Record{
+		Domain:               d.hostname,
+		OrganizationalDomain: d.orgDomain,
+		Discarded:            d.discarded,
+	}
+	if d.name == "" {
+		return r, nil
+	}
+
+	tags := d.tags
+	if d.noPolicy {
+		tags = maps.Clone(tags)
+		for _, tag := range []string{"p", "sp"} {
+			if !validPolicies[tags[tag]] {
+				delete(tags, tag)
+			}
+		}
+	}
+	config, err := parseConfig(tags)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid DMARC record at %s: %w", d.name, err)
+	}
+	r.Name, r.Value, r.Config = d.name, d.value, config
+	_, r.Policy = d.policy(false)
+
+	return r, nil
+}
+
+// LookupEffectivePolicy performs the DMARC policy discovery of RFC 7489
+// section 6.6.3 for hostname, applying np= (RFC 9091) when nonExistent.
+func LookupEffectivePolicy(hostname string, nonExistent bool, resolver Resolver) (EffectivePolicy, error) {
+	d, err := discover(hostname, resolver)
+	if err != nil {
+		return EffectivePolicy{}, err
+	}
+
+	r := EffectivePolicy{
+		Hostname:             d.hostname,
+		OrganizationalDomain: d.orgDomain,
+		RecordName:           d.name,
+		Record:               d.value,
+	}
+	r.PolicyTag, r.Policy = d.policy(nonExistent)
+
+	return r, nil
+}
+
+type discovery struct {
+	hostname  string
+	orgDomain string
+	name      string
+	value     string
+	tags      map[string]string
+	// noPolicy is set when the p= or sp= tag of the record is not valid and
+	// receivers act as if it were p=none.
+	noPolicy  bool
+	discarded string
+}
+
+// discover looks up the DMARC record of hostname, then of its organizational
+// domain, stopping at the first one found.
+func discover(hostname string, resolver Resolver) (discovery, error) {
+	orgDomain, err := OrganizationalDomain(hostname)
+	if err != nil {
+		return discovery{}, err
+	}
+
+	d := discovery{
+		hostname:  normalizeHostname(hostname),
+		orgDomain: orgDomain,
+	}
+
+	names := []string{"_dmarc." + d.hostname}
+	if d.hostname != orgDomain {
+		names = append(names, "_dmarc."+orgDomain)
+	}
+	for _, name := range names {
+		value, tags, err := lookupRecord(name, resolver)
+		if errors.Is(err, errMultipleRecords) {
+			d.discarded = fmt.Sprintf("%s publishes more than one DMARC record", name)
+			break
+		}
+		if err != nil {
+			return discovery{}, err
+		}
+		if value == "" {
+			continue
+		}
+
+		// Records with an invalid p= or sp= act as p=none when they request
+		// aggregate reports, and are discarded otherwise.
+		if !validPolicies[tags["p"]] || tags["sp"] != "" && !validPolicies[tags["sp"]] {
+			if !hasReportURI(tags["rua"]) {
+				d.discarded = fmt.Sprintf("the DMARC record at %s has no valid policy and no valid aggregate report URI", name)
+				break
+			}
+			d.noPolicy = true
+		}
+		d.name, d.value, d.tags = name, value, tags
+		break
+	}
+
+	return d, nil
+}
+
+// policy returns the tag and value of the policy applied to mail from the
+// hostname of d, or empty strings when no record applies.
+func (d discovery) policy(nonExistent bool) (string, string) {
+	switch {
+	case d.name == "":
+		return "", ""
+	case d.noPolicy:
+		return "p", "none"
+	case d.name == "_dmarc."+d.hostname:
+		return "p", d.tags["p"]
+	case nonExistent && validPolicies[d.tags["np"]]:
+		return "np", d.tags["np"]
+	case d.tags["sp"] != "":
+		return "sp", d.tags["sp"]
+	}
+	return "p", d.tags["p"]
+}
+
+func hasReportURI(rua string) bool {
+	for _, uri := range splitURIs(rua) {
+		if validateReportURI(uri) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// errMultipleRecords is returned by lookupRecord when name publishes more
+// than one DMARC record, which receivers discard.
+var errMultipleRecords = errors.New("multiple DMARC records")
+
+// lookupRecord returns the single DMARC record published at name along with
+// its tags, or an empty record when there is none.
+func lookupRecord(name string, resolver Resolver) (string, map[string]string, error) {
+	txts, err := resolver.GetTXT(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to look up %s: %w", name, err)
+	}
+
+	var record string
+	var tags map[string]string
+	for _, txt := range txts {
+		t, ok := parseTags(txt)
+		if !ok {
+			continue
+		}
+		if record != "" {
+			return "", nil, errMultipleRecords
+		}
+		record, tags = txt, t
+	}
+
+	return record, tags, nil
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+}
@@ -0,0 +1,312 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dmarcbuilder_test
+
+import (
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/testutil"
+)
+
+func TestOrganizationalDomain(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Organizational Domain",
+			hostname: "example.com",
+			want:     "example.com",
+		},
+		{
+			name:     "Subdomain",
+			hostname: "mail.eu.example.com",
+			want:     "example.com",
+		},
+		{
+			name:     "Multi Label Public Suffix",
+			hostname: "mail.example.co.uk",
+			want:     "example.co.uk",
+		},
+		{
+			name:     "Trailing Dot And Case",
+			hostname: "Mail.Example.COM.",
+			want:     "example.com",
+		},
+		{
+			name:     "Public Suffix",
+			hostname: "co.uk",
+			wantErr:  true,
+		},
+		{
+			name:     "Empty",
+			hostname: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dmarcbuilder.OrganizationalDomain(tt.hostname)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OrganizationalDomain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("OrganizationalDomain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupEffectivePolicy(t *testing.T) {
+	mock := testutil.NewMockDNSResolver()
+
+	tests := []struct {
+		name        string
+		hostname    string
+		nonExistent bool
+		want        dmarcbuilder.EffectivePolicy
+		wantErr     bool
+	}{
+		{
+			name:     "Organizational Domain Record",
+			hostname: "example.com",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "example.com",
+				OrganizationalDomain: "example.com",
+				RecordName:           "_dmarc.example.com",
+				Record:               "v=DMARC1; p=reject; sp=quarantine; rua=mailto:dmarc@example.com",
+				PolicyTag:            "p",
+				Policy:               "reject",
+			},
+		},
+		{
+			name:     "Subdomain Inherits sp",
+			hostname: "www.example.com",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "www.example.com",
+				OrganizationalDomain: "example.com",
+				RecordName:           "_dmarc.example.com",
+				Record:               "v=DMARC1; p=reject; sp=quarantine; rua=mailto:dmarc@example.com",
+				PolicyTag:            "sp",
+				Policy:               "quarantine",
+			},
+		},
+		{
+			name:     "Subdomain Inherits p Without sp",
+			hostname: "www.example.org",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "www.example.org",
+				OrganizationalDomain: "example.org",
+				RecordName:           "_dmarc.example.org",
+				Record:               "v=DMARC1; p=quarantine; np=reject",
+				PolicyTag:            "p",
+				Policy:               "quarantine",
+			},
+		},
+		{
+			name:        "Non Existent Subdomain Uses np",
+			hostname:    "nx.example.org",
+			nonExistent: true,
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "nx.example.org",
+				OrganizationalDomain: "example.org",
+				RecordName:           "_dmarc.example.org",
+				Record:               "v=DMARC1; p=quarantine; np=reject",
+				PolicyTag:            "np",
+				Policy:               "reject",
+			},
+		},
+		{
+			name:     "Subdomain With Own Record",
+			hostname: "mail.example.org",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "mail.example.org",
+				OrganizationalDomain: "example.org",
+				RecordName:           "_dmarc.mail.example.org",
+				Record:               "v=DMARC1; p=none",
+				PolicyTag:            "p",
+				Policy:               "none",
+			},
+		},
+		{
+			name:     "Unrelated TXT Records Are Ignored",
+			hostname: "shop.example.co.uk",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "shop.example.co.uk",
+				OrganizationalDomain: "example.co.uk",
+				RecordName:           "_dmarc.example.co.uk",
+				Record:               "v=DMARC1; p=reject",
+				PolicyTag:            "p",
+				Policy:               "reject",
+			},
+		},
+		{
+			name:     "No Record",
+			hostname: "www.example.edu",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "www.example.edu",
+				OrganizationalDomain: "example.edu",
+			},
+		},
+		{
+			name:     "Multiple Records",
+			hostname: "www.example.net",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "www.example.net",
+				OrganizationalDomain: "example.net",
+			},
+		},
+		{
+			name:     "Reports Without Policy",
+			hostname: "reports.example.org",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "reports.example.org",
+				OrganizationalDomain: "example.org",
+				RecordName:           "_dmarc.reports.example.org",
+				Record:               "v=DMARC1; rua=mailto:a@example.com",
+				PolicyTag:            "p",
+				Policy:               "none",
+			},
+		},
+		{
+			name:     "Invalid sp With Reports",
+			hostname: "badsp.example.org",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "badsp.example.org",
+				OrganizationalDomain: "example.org",
+				RecordName:           "_dmarc.badsp.example.org",
+				Record:               "v=DMARC1; p=reject; sp=bogus; rua=mailto:dmarc@example.org",
+				PolicyTag:            "p",
+				Policy:               "none",
+			},
+		},
+		{
+			name:     "Invalid Policy Without Reports Does Not Fall Back",
+			hostname: "nopolicy.example.com",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "nopolicy.example.com",
+				OrganizationalDomain: "example.com",
+			},
+		},
+		{
+			name:     "Invalid sp Without Valid Reports Does Not Fall Back",
+			hostname: "nosp.example.com",
+			want: dmarcbuilder.EffectivePolicy{
+				Hostname:             "nosp.example.com",
+				OrganizationalDomain: "example.com",
+			},
+		},
+		{
+			name:     "Public Suffix",
+			hostname: "com",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dmarcbuilder.LookupEffectivePolicy(tt.hostname, tt.nonExistent, mock)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LookupEffectivePolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LookupEffectivePolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupRecord(t *testing.T) {
+	mock := testutil.NewMockDNSResolver()
+
+	tests := []struct {
+		name          string
+		domain        string
+		wantName      string
+		wantP         string
+		wantSP        string
+		wantPolicy    string
+		wantDiscarded string
+		wantErr       bool
+	}{
+		{
+			name:       "Record At Domain",
+			domain:     "mail.example.org",
+			wantName:   "_dmarc.mail.example.org",
+			wantP:      "none",
+			wantPolicy: "none",
+		},
+		{
+			name:       "Organizational Domain Fallback",
+			domain:     "www.example.com",
+			wantName:   "_dmarc.example.com",
+			wantP:      "reject",
+			wantSP:     "quarantine",
+			wantPolicy: "quarantine",
+		},
+		{
+			name:   "No Record",
+			domain: "example.edu",
+		},
+		{
+			name:          "Multiple Records",
+			domain:        "example.net",
+			wantDiscarded: "_dmarc.example.net publishes more than one DMARC record",
+		},
+		{
+			name:       "Reports Without Policy",
+			domain:     "reports.example.org",
+			wantName:   "_dmarc.reports.example.org",
+			wantPolicy: "none",
+		},
+		{
+			name:       "Invalid sp With Reports",
+			domain:     "badsp.example.org",
+			wantName:   "_dmarc.badsp.example.org",
+			wantP:      "reject",
+			wantPolicy: "none",
+		},
+		{
+			name:          "Invalid Policy Without Reports Does Not Fall Back",
+			domain:        "nopolicy.example.com",
+			wantDiscarded: "the DMARC record at _dmarc.nopolicy.example.com has no valid policy and no valid aggregate report URI",
+		},
+		{
+			name:          "Invalid sp Without Valid Reports Does Not Fall Back",
+			domain:        "nosp.example.com",
+			wantDiscarded: "the DMARC record at _dmarc.nosp.example.com has no valid policy and no valid aggregate report URI",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dmarcbuilder.LookupRecord(tt.domain, mock)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LookupRecord() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("LookupRecord().Name = %v, want %v", got.Name, tt.wantName)
+			}
+			if got.Config.Policy != tt.wantP {
+				t.Errorf("LookupRecord().Config.Policy = %v, want %v", got.Config.Policy, tt.wantP)
+			}
+			if got.Config.SubdomainPolicy != tt.wantSP {
+				t.Errorf("LookupRecord().Config.SubdomainPolicy = %v, want %v", got.Config.SubdomainPolicy, tt.wantSP)
+			}
+			if got.Policy != tt.wantPolicy {
+				t.Errorf("LookupRecord().Policy = %v, want %v", got.Policy, tt.wantPolicy)
+			}
+			if got.Discarded != tt.wantDiscarded {
+				t.Errorf("LookupRecord().Discarded = %v, want %v", got.Discarded, tt.wantDiscarded)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dmarcbuilder
+
+import (
+	"time"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// RampPercent applies each policy to a growing pct= share of messages, while
+// RampTesting sets the DMARCbis t=y flag before enforcing each policy.
+const (
+	RampPercent = "pct"
+	RampTesting = "t"
+)
+
+// RolloutStage is the policy part of a DMARC rollout stage.
+type RolloutStage struct {
+	Policy  string
+	Percent int32
+	Testing bool
+}
+
+var rolloutStages = map[string]map[string][]RolloutStage{
+	RampPercent: {
+		"none": {
+			{Policy: "none"},
+		},
+		"quarantine": {
+			{Policy: "none"},
+			{Policy: "quarantine", Percent: 10},
+			{Policy: "quarantine", Percent: 50},
+			{Policy: "quarantine", Percent: 100},
+		},
+		"reject": {
+			{Policy: "none"},
+			{Policy: "quarantine", Percent: 10},
+			{Policy: "quarantine", Percent: 50},
+			{Policy: "quarantine", Percent: 100},
+			{Policy: "reject"},
+		},
+	},
+	RampTesting: {
+		"none": {
+			{Policy: "none"},
+		},
+		"quarantine": {
+			{Policy: "none"},
+			{Policy: "quarantine", Testing: true},
+			{Policy: "quarantine"},
+		},
+		"reject": {
+			{Policy: "none"},
+			{Policy: "quarantine", Testing: true},
+			{Policy: "quarantine"},
+			{Policy: "reject", Testing: true},
+			{Policy: "reject"},
+		},
+	},
+}
+
+// RolloutStages returns the stages of a rollout from p=none to target using
+// ramp, RampPercent when empty.
+func RolloutStages(target string, ramp string) ([]RolloutStage, error) {
+	if ramp == "" {
+		ramp = RampPercent
+	}
+	byTarget, ok := rolloutStages[ramp]
+	if !ok {
+		return nil, fielderror.Errorf("Ramp", "invalid DMARC rollout ramp %q, must be one of '%s', '%s'", ramp, RampPercent, RampTesting)
+	}
+	stages, ok := byTarget[target]
+	if !ok {
+		return nil, fielderror.New("Target", "invalid DMARC rollout target policy")
+	}
+	return stages, nil
+}
+
+// Rollout returns base with the policy of the given rollout stage applied.
+// base must not set the policy, percentage or testing mode.
+func Rollout(base DMARCConfig, target string, ramp string, stage int) (DMARCConfig, error) {
+	stages, err := RolloutStages(target, ramp)
+	if err != nil {
+		return DMARCConfig{}, err
+	}
+
+	if base.Policy != "" {
+		return DMARCConfig{}, fielderror.New("Policy", "policy is set by the rollout stage and must not be set")
+	}
+	if base.Percent != 0 || base.PercentSet {
+		return DMARCConfig{}, fielderror.New("Percent", "percent is set by the rollout stage and must not be set")
+	}
+	if base.Testing {
+		return DMARCConfig{}, fielderror.New("Testing", "testing is set by the rollout stage and must not be set")
+	}
+	if stage < 0 {
+		return DMARCConfig{}, fielderror.New("Stage", "rollout stage must not be negative")
+	}
+
+	s := stages[min(stage, len(stages)-1)]
+	base.Policy, base.Percent, base.Testing = s.Policy, s.Percent, s.Testing
+
+	return base, nil
+}
+
+// ScheduledStage returns the rollout stage in effect at now, given the start
+// date of every stage after the first.
+func ScheduledStage(schedule []time.Time, now time.Time) (int, error) {
+	stage := 0
+	for i, start := range schedule {
+		if i > 0 && !start.After(schedule[i-1]) {
+			return 0, fielderror.Errorf(fielderror.Index("Schedule", i), "schedule dates must be in ascending order, %s is not after %s", start.Format(time.RFC3339), schedule[i-1].Format(time.RFC3339))
+		}
+		if !now.Before(start) {
+			stage = i + 1
+		}
+	}
+	return stage, nil
+}
+
+// ScheduledRollout returns Rollout at the stage ScheduledStage finds for now,
+// along with the stage index.
+func ScheduledRollout(base DMARCConfig, target string, ramp string, schedule []time.Time, now time.Time) (DMARCConfig, int, error) {
+	stages, err := RolloutStages(target, ramp)
+	if err != nil {
+		return DMARCConfig{}, 0, err
+	}
+	if len(schedule) > len(stages)-1 {
+		return DMARCConfig{}, 0, fielderror.Errorf("Schedule", "rollout to %s has %d stages, the schedule must have at most %d dates", target, len(stages), len(stages)-1)
+	}
+
+	stage, err := ScheduledStage(schedule, now)
+	if err != nil {
+		return DMARCConfig{}, 0, err
+	}
+
+	config, err := Rollout(base, target, ramp, stage)
+	return config, stage, err
+}
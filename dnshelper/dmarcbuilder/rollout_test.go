@@ -0,0 +1,209 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dmarcbuilder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+)
+
+func TestRollout(t *testing.T) {
+	base := dmarcbuilder.DMARCConfig{
+		RUA: []string{"mailto:dmarc@example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		base    dmarcbuilder.DMARCConfig
+		target  string
+		ramp    string
+		stage   int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Reject Stage 0",
+			base:   base,
+			target: "reject",
+			stage:  0,
+			want:   "v=DMARC1; p=none; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:   "Reject Stage 1",
+			base:   base,
+			target: "reject",
+			stage:  1,
+			want:   "v=DMARC1; p=quarantine; pct=10; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:   "Reject Stage 3",
+			base:   base,
+			target: "reject",
+			ramp:   dmarcbuilder.RampPercent,
+			stage:  3,
+			want:   "v=DMARC1; p=quarantine; pct=100; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:   "Reject Final Stage",
+			base:   base,
+			target: "reject",
+			stage:  4,
+			want:   "v=DMARC1; p=reject; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:   "Past Final Stage",
+			base:   base,
+			target: "quarantine",
+			stage:  10,
+			want:   "v=DMARC1; p=quarantine; pct=100; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:   "Testing Ramp",
+			base:   base,
+			target: "reject",
+			ramp:   dmarcbuilder.RampTesting,
+			stage:  3,
+			want:   "v=DMARC1; p=reject; t=y; rua=mailto:dmarc@example.com",
+		},
+		{
+			name:    "Invalid Target",
+			base:    base,
+			target:  "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Ramp",
+			base:    base,
+			target:  "reject",
+			ramp:    "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "Negative Stage",
+			base:    base,
+			target:  "reject",
+			stage:   -1,
+			wantErr: true,
+		},
+		{
+			name:    "Policy Set In Base",
+			base:    dmarcbuilder.DMARCConfig{Policy: "reject"},
+			target:  "reject",
+			wantErr: true,
+		},
+		{
+			name:    "Percent Set In Base",
+			base:    dmarcbuilder.DMARCConfig{Percent: 100},
+			target:  "reject",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := dmarcbuilder.Rollout(tt.base, tt.target, tt.ramp, tt.stage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rollout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := dmarcbuilder.DmarcBuilder(config)
+			if err != nil {
+				t.Fatalf("DmarcBuilder() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DmarcBuilder(Rollout()) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduledRollout(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	schedule := []time.Time{date("2026-01-05"), date("2026-01-19"), date("2026-02-02"), date("2026-03-02")}
+
+	tests := []struct {
+		name      string
+		schedule  []time.Time
+		now       time.Time
+		wantStage int
+		want      dmarcbuilder.RolloutStage
+		wantErr   bool
+	}{
+		{
+			name:      "Before Schedule",
+			schedule:  schedule,
+			now:       date("2025-12-01"),
+			wantStage: 0,
+			want:      dmarcbuilder.RolloutStage{Policy: "none"},
+		},
+		{
+			name:      "On First Date",
+			schedule:  schedule,
+			now:       date("2026-01-05"),
+			wantStage: 1,
+			want:      dmarcbuilder.RolloutStage{Policy: "quarantine", Percent: 10},
+		},
+		{
+			name:      "Between Dates",
+			schedule:  schedule,
+			now:       date("2026-02-10"),
+			wantStage: 3,
+			want:      dmarcbuilder.RolloutStage{Policy: "quarantine", Percent: 100},
+		},
+		{
+			name:      "After Schedule",
+			schedule:  schedule,
+			now:       date("2026-10-19"),
+			wantStage: 4,
+			want:      dmarcbuilder.RolloutStage{Policy: "reject"},
+		},
+		{
+			name:      "Partial Schedule Holds Last Stage",
+			schedule:  schedule[:2],
+			now:       date("2026-10-19"),
+			wantStage: 2,
+			want:      dmarcbuilder.RolloutStage{Policy: "quarantine", Percent: 50},
+		},
+		{
+			name:     "Unordered Schedule",
+			schedule: []time.Time{date("2026-02-02"), date("2026-01-19")},
+			now:      date("2026-10-19"),
+			wantErr:  true,
+		},
+		{
+			name:     "Too Many Dates",
+			schedule: append(schedule, date("2026-04-01")),
+			now:      date("2026-10-19"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, stage, err := dmarcbuilder.ScheduledRollout(dmarcbuilder.DMARCConfig{}, "reject", "", tt.schedule, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ScheduledRollout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if stage != tt.wantStage {
+				t.Errorf("ScheduledRollout() stage = %v, want %v", stage, tt.wantStage)
+			}
+			got := dmarcbuilder.RolloutStage{Policy: config.Policy, Percent: config.Percent, Testing: config.Testing}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ScheduledRollout() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
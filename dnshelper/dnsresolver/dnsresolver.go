@@ -0,0 +1,89 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package dnsresolver provides the live DNS resolver of the builder packages,
+// which each declare the Resolver interface they need.
+package dnsresolver
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type LiveResolver struct{}
+
+// GetTXT returns the TXT records published at name. A name without TXT
+// records, or which does not exist, yields no records and no error.
+func (l LiveResolver) GetTXT(name string) ([]string, error) {
+	records, err := net.LookupTXT(name)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return records, err
+}
+
+// GetCAA returns the CAA records at name itself in the RFC 3597 generic form,
+// without following CNAME records.
+func (l LiveResolver) GetCAA(name string) ([]string, error) {
+	answers, err := query(name, typeCAA)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+	for _, rr := range answers {
+		u, ok := rr.Body.(*dnsmessage.UnknownResource)
+		if !ok {
+			continue
+		}
+		records = append(records, fmt.Sprintf(`\# %d %s`, len(u.Data), hex.EncodeToString(u.Data)))
+	}
+	return records, nil
+}
+
+// GetCNAME returns the target of the CNAME record at name, or an empty string
+// when name is not an alias.
+func (l LiveResolver) GetCNAME(name string) (string, error) {
+	answers, err := query(name, dnsmessage.TypeCNAME)
+	if err != nil {
+		return "", err
+	}
+	for _, rr := range answers {
+		if c, ok := rr.Body.(*dnsmessage.CNAMEResource); ok {
+			return strings.TrimSuffix(c.CNAME.String(), "."), nil
+		}
+	}
+	return "", nil
+}
+
+// GetMX returns the MX hosts of name in preference order, without trailing
+// dots. A null MX (RFC 7505) is returned as ".".
+func (l LiveResolver) GetMX(name string) ([]string, error) {
+	records, err := net.LookupMX(name)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]string, 0, len(records))
+	for _, mx := range records {
+		host := mx.Host
+		if host != "." {
+			host = strings.TrimSuffix(host, ".")
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
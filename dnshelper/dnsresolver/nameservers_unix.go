@@ -0,0 +1,37 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package dnsresolver
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+)
+
+// resolvConf is where the nameservers used for queries the standard library
+// resolver cannot make are read from.
+const resolvConf = "/etc/resolv.conf"
+
+// systemNameservers returns the nameservers listed in resolv.conf, or none
+// when it is missing.
+func systemNameservers() []string {
+	f, err := os.Open(resolvConf)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var servers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, net.JoinHostPort(fields[1], "53"))
+		}
+	}
+	return servers
+}
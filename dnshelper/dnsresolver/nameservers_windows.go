@@ -0,0 +1,48 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package dnsresolver
+
+import (
+	"errors"
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// systemNameservers returns the DNS servers of the network adapters that are
+// up and have a gateway, as the standard library resolver does on Windows.
+func systemNameservers() []string {
+	size := uint32(15000)
+	var buf []byte
+	for {
+		buf = make([]byte, size)
+		err := windows.GetAdaptersAddresses(windows.AF_UNSPEC, windows.GAA_FLAG_INCLUDE_GATEWAYS|windows.GAA_FLAG_SKIP_UNICAST|windows.GAA_FLAG_SKIP_ANYCAST|windows.GAA_FLAG_SKIP_MULTICAST, 0, (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])), &size)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, windows.ERROR_BUFFER_OVERFLOW) || size <= uint32(len(buf)) {
+			return nil
+		}
+	}
+
+	var servers []string
+	for aa := (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])); aa != nil; aa = aa.Next {
+		if aa.OperStatus != windows.IfOperStatusUp || aa.FirstGatewayAddress == nil {
+			continue
+		}
+		for dns := aa.FirstDnsServerAddress; dns != nil; dns = dns.Next {
+			ip := dns.Address.IP()
+			// fec0::/10 are the deprecated site local DNS addresses Windows
+			// sets when no IPv6 DNS server is configured.
+			if ip == nil || ip.To4() == nil && ip[0] == 0xfe && ip[1]&0xc0 == 0xc0 {
+				continue
+			}
+			servers = append(servers, net.JoinHostPort(ip.String(), "53"))
+		}
+	}
+	return servers
+}
@@ -0,0 +1,178 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dnsresolver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// typeCAA is the CAA resource record type (RFC 8659), which dnsmessage does
+// not define.
+const typeCAA dnsmessage.Type = 257
+
+const (
+	queryTimeout = 5 * time.Second
+	// udpSize is the EDNS(0) UDP payload size advertised in queries.
+	udpSize = 1232
+)
+
+// localNameservers are queried when the system configuration lists no
+// nameservers, like the standard library resolver does.
+var localNameservers = []string{"127.0.0.1:53", "[::1]:53"}
+
+// query sends a recursive query to the system nameservers and returns the
+// answers owned by name, none when name does not exist.
+func query(name string, qtype dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	qname, err := dnsmessage.NewName(fqdn(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", name, err)
+	}
+
+	servers := systemNameservers()
+	local := len(servers) == 0
+	if local {
+		servers = localNameservers
+	}
+
+	var errs []error
+	for _, server := range servers {
+		msg, err := exchange(server, qname, qtype)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		switch msg.RCode {
+		case dnsmessage.RCodeSuccess:
+		case dnsmessage.RCodeNameError:
+			return nil, nil
+		default:
+			errs = append(errs, fmt.Errorf("%s: %s returned %s", name, server, msg.RCode))
+			continue
+		}
+
+		var answers []dnsmessage.Resource
+		for _, rr := range msg.Answers {
+			if rr.Header.Type == qtype && strings.EqualFold(rr.Header.Name.String(), qname.String()) {
+				answers = append(answers, rr)
+			}
+		}
+		return answers, nil
+	}
+	if local {
+		return nil, fmt.Errorf("failed to query %s, no nameservers are configured on this system and the local resolver fallback failed: %w", name, errors.Join(errs...))
+	}
+	return nil, fmt.Errorf("failed to query %s: %w", name, errors.Join(errs...))
+}
+
+// exchange sends a single query over UDP, retrying over TCP when the answer is
+// truncated.
+func exchange(server string, name dnsmessage.Name, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	id := uint16(rand.Uint32())
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(udpSize, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+	packet, err := b.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := exchangeUDP(server, packet, id)
+	if err != nil || !msg.Truncated {
+		return msg, err
+	}
+	return exchangeTCP(server, packet, id)
+}
+
+func exchangeUDP(server string, packet []byte, id uint16) (*dnsmessage.Message, error) {
+	conn, err := net.DialTimeout("udp", server, queryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(queryTimeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.Write(packet); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, udpSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil || msg.ID != id || !msg.Response {
+			// Ignore stray or malformed packets until the deadline.
+			continue
+		}
+		return &msg, nil
+	}
+}
+
+func exchangeTCP(server string, packet []byte, id uint16) (*dnsmessage.Message, error) {
+	conn, err := net.DialTimeout("tcp", server, queryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(queryTimeout)); err != nil {
+		return nil, err
+	}
+
+	framed := binary.BigEndian.AppendUint16(nil, uint16(len(packet)))
+	if _, err := conn.Write(append(framed, packet...)); err != nil {
+		return nil, err
+	}
+	var length uint16
+	if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(buf); err != nil {
+		return nil, err
+	}
+	if msg.ID != id {
+		return nil, fmt.Errorf("%s answered with mismatched query id", server)
+	}
+	return &msg, nil
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,63 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fielderror provides the error type the builder packages return for
+// an invalid configuration field.
+package fielderror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Error struct {
+	// Field is the path of the offending field, e.g. "SubdomainPolicy" or
+	// "Issuewild[2]".
+	Field string
+	Err   error
+}
+
+func New(field string, text string) *Error {
+	return &Error{Field: field, Err: errors.New(text)}
+}
+
+func Errorf(field string, format string, a ...any) *Error {
+	return &Error{Field: field, Err: fmt.Errorf(format, a...)}
+}
+
+// Wrap attaches a field to err, re-rooting field errors: "Parameters[0]"
+// wrapped under "Issue[1]" becomes "Issue[1].Parameters[0]".
+func Wrap(field string, err error) *Error {
+	var fe *Error
+	if errors.As(err, &fe) {
+		sep := "."
+		if strings.HasPrefix(fe.Field, "[") {
+			sep = ""
+		}
+		return &Error{Field: field + sep + fe.Field, Err: fe.Err}
+	}
+	return &Error{Field: field, Err: err}
+}
+
+// Index returns the path of the i-th element of a list field.
+func Index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
+
+func (e *Error) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Split returns the top-level field name and the remaining path, e.g.
+// "Issuewild" and "[2]" for "Issuewild[2]".
+func (e *Error) Split() (string, string) {
+	if i := strings.IndexAny(e.Field, "[."); i >= 0 {
+		return e.Field[:i], e.Field[i:]
+	}
+	return e.Field, ""
+}
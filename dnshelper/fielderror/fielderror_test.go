@@ -0,0 +1,74 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fielderror_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+func TestError(t *testing.T) {
+	err := fielderror.New(fielderror.Index("Issuewild", 2), "invalid issuer domain")
+	if err.Error() != "Issuewild[2]: invalid issuer domain" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	root, rest := err.Split()
+	if root != "Issuewild" || rest != "[2]" {
+		t.Errorf("Split() = %q, %q", root, rest)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     string
+		err       error
+		wantField string
+		wantRoot  string
+		wantRest  string
+	}{
+		{
+			name:      "plain error",
+			field:     "Parts",
+			err:       errors.New("failed to parse SPF record"),
+			wantField: "Parts",
+			wantRoot:  "Parts",
+		},
+		{
+			name:      "nested field",
+			field:     "Issue[1]",
+			err:       fielderror.New("Parameters[0]", "unknown parameter"),
+			wantField: "Issue[1].Parameters[0]",
+			wantRoot:  "Issue",
+			wantRest:  "[1].Parameters[0]",
+		},
+		{
+			name:      "nested index",
+			field:     "Issue",
+			err:       fielderror.New("[3]", "invalid issuer domain"),
+			wantField: "Issue[3]",
+			wantRoot:  "Issue",
+			wantRest:  "[3]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fielderror.Wrap(tt.field, tt.err)
+			if got.Field != tt.wantField {
+				t.Errorf("Wrap().Field = %q, want %q", got.Field, tt.wantField)
+			}
+			root, rest := got.Split()
+			if root != tt.wantRoot || rest != tt.wantRest {
+				t.Errorf("Split() = %q, %q, want %q, %q", root, rest, tt.wantRoot, tt.wantRest)
+			}
+			var fe *fielderror.Error
+			if !errors.As(got, &fe) {
+				t.Errorf("errors.As() failed for %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package mtastsbuilder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+type Resolver interface {
+	GetMX(name string) ([]string, error)
+}
+
+// MXCheckResult is the outcome of checking MX records against the mx patterns
+// of an MTA-STS policy.
+type MXCheckResult struct {
+	// Hosts are the MX hosts of the domain, lowercased.
+	Hosts []string
+	// Mismatches are the hosts no pattern matches. Sending MTAs enforcing the
+	// policy will not deliver mail to them.
+	Mismatches []string
+	// UnusedPatterns are the patterns no host matches, often left over from a
+	// previous mail provider.
+	UnusedPatterns []string
+}
+
+// MatchMX reports whether host matches the MX pattern as defined by RFC 8461
+// section 4.1, ignoring case and trailing dots.
+func MatchMX(pattern string, host string) bool {
+	pattern, host = normalizeHost(pattern), normalizeHost(host)
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		label, rest, found := strings.Cut(host, ".")
+		return found && label != "" && rest == suffix
+	}
+	return pattern == host
+}
+
+// CheckMX looks up the MX records of domain and checks each host against the
+// mx patterns of an MTA-STS policy.
+func CheckMX(domain string, patterns []string, resolver Resolver) (MXCheckResult, error) {
+	domain = normalizeHost(domain)
+	if !validate.Hostname(domain) {
+		return MXCheckResult{}, fielderror.Errorf("Domain", "invalid domain %q", domain)
+	}
+	normalized, err := normalizePatterns(patterns)
+	if err != nil {
+		return MXCheckResult{}, err
+	}
+
+	records, err := resolver.GetMX(domain)
+	if err != nil {
+		return MXCheckResult{}, fmt.Errorf("failed to look up the MX records of %s: %w", domain, err)
+	}
+	if len(records) == 0 {
+		return MXCheckResult{}, fmt.Errorf("%s has no MX records", domain)
+	}
+	if slices.Contains(records, ".") {
+		return MXCheckResult{}, fmt.Errorf("%s publishes a null MX record and does not accept mail", domain)
+	}
+
+	return checkHosts(normalized, records), nil
+}
+
+// CheckMXHosts checks hosts against the mx patterns of an MTA-STS policy like
+// CheckMX, without looking up any record.
+func CheckMXHosts(hosts []string, patterns []string) (MXCheckResult, error) {
+	normalized, err := normalizePatterns(patterns)
+	if err != nil {
+		return MXCheckResult{}, err
+	}
+	if len(hosts) == 0 {
+		return MXCheckResult{}, fielderror.New("Hosts", "at least one MX host is required")
+	}
+	for i, host := range hosts {
+		if !validate.Hostname(normalizeHost(host)) {
+			return MXCheckResult{}, fielderror.Errorf(fielderror.Index("Hosts", i), "invalid MX host %q", host)
+		}
+	}
+
+	return checkHosts(normalized, hosts), nil
+}
+
+func normalizePatterns(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, fielderror.New("MX", "at least one MX pattern is required")
+	}
+	normalized := make([]string, 0, len(patterns))
+	for i, pattern := range patterns {
+		p, err := normalizePattern(pattern)
+		if err != nil {
+			return nil, fielderror.Wrap(fielderror.Index("MX", i), err)
+		}
+		normalized = append(normalized, p)
+	}
+	return normalized, nil
+}
+
+// checkHosts matches hosts against normalized patterns.
+func checkHosts(patterns []string, hosts []string) MXCheckResult {
+	r := MXCheckResult{Hosts: []string{}, Mismatches: []string{}, UnusedPatterns: []string{}}
+	used := make([]bool, len(patterns))
+	for _, host := range hosts {
+		host = normalizeHost(host)
+		r.Hosts = append(r.Hosts, host)
+
+		matched := false
+		for i, pattern := range patterns {
+			if MatchMX(pattern, host) {
+				used[i], matched = true, true
+			}
+		}
+		if !matched {
+			r.Mismatches = append(r.Mismatches, host)
+		}
+	}
+	for i, pattern := range patterns {
+		if !used[i] {
+			r.UnusedPatterns = append(r.UnusedPatterns, pattern)
+		}
+	}
+	return r
+}
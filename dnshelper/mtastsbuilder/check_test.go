@@ -0,0 +1,186 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package mtastsbuilder_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/mtastsbuilder"
+)
+
+type testResolver map[string][]string
+
+func (r testResolver) GetMX(name string) ([]string, error) {
+	if name == "servfail.example" {
+		return nil, errors.New("SERVFAIL")
+	}
+	return r[name], nil
+}
+
+var checkResolver = testResolver{
+	"example.com":        {"mx1.example.com", "MX2.Example.com"},
+	"example.org":        {"example-org.mail.protection.outlook.com", "legacy-mx.example.net"},
+	"nullmx.example.com": {"."},
+}
+
+func TestMatchMX(t *testing.T) {
+	tests := []struct {
+		pattern string
+		host    string
+		want    bool
+	}{
+		{pattern: "mx.example.com", host: "mx.example.com", want: true},
+		{pattern: "mx.example.com", host: "MX.Example.com.", want: true},
+		{pattern: "mx.example.com", host: "mx2.example.com"},
+		{pattern: "*.example.com", host: "mx.example.com", want: true},
+		{pattern: "*.example.com", host: "a.mx.example.com"},
+		{pattern: "*.example.com", host: "example.com"},
+		{pattern: "*.example.com", host: ".example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.host, func(t *testing.T) {
+			if got := mtastsbuilder.MatchMX(tt.pattern, tt.host); got != tt.want {
+				t.Errorf("MatchMX() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMX(t *testing.T) {
+	tests := []struct {
+		name     string
+		domain   string
+		patterns []string
+		want     mtastsbuilder.MXCheckResult
+		wantErr  bool
+	}{
+		{
+			name:     "All Match",
+			domain:   "example.com",
+			patterns: []string{"*.example.com"},
+			want:     mtastsbuilder.MXCheckResult{Hosts: []string{"mx1.example.com", "mx2.example.com"}, Mismatches: []string{}, UnusedPatterns: []string{}},
+		},
+		{
+			name:     "Migration Leftovers",
+			domain:   "example.org",
+			patterns: []string{"*.mail.protection.outlook.com", "aspmx.l.google.com"},
+			want: mtastsbuilder.MXCheckResult{
+				Hosts:          []string{"example-org.mail.protection.outlook.com", "legacy-mx.example.net"},
+				Mismatches:     []string{"legacy-mx.example.net"},
+				UnusedPatterns: []string{"aspmx.l.google.com"},
+			},
+		},
+		{
+			name:     "No MX Records",
+			domain:   "example.net",
+			patterns: []string{"mx.example.net"},
+			wantErr:  true,
+		},
+		{
+			name:     "Null MX",
+			domain:   "nullmx.example.com",
+			patterns: []string{"mx.example.com"},
+			wantErr:  true,
+		},
+		{
+			name:     "Lookup Error",
+			domain:   "servfail.example",
+			patterns: []string{"mx.example.com"},
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid Pattern",
+			domain:   "example.com",
+			patterns: []string{"mx.*.example.com"},
+			wantErr:  true,
+		},
+		{
+			name:    "No Patterns",
+			domain:  "example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mtastsbuilder.CheckMX(tt.domain, tt.patterns, checkResolver)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckMX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckMX() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMXHosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		hosts     []string
+		patterns  []string
+		want      mtastsbuilder.MXCheckResult
+		wantField string
+	}{
+		{
+			name:     "All Match",
+			hosts:    []string{"MX1.example.com.", "mx2.example.com"},
+			patterns: []string{"*.example.com"},
+			want:     mtastsbuilder.MXCheckResult{Hosts: []string{"mx1.example.com", "mx2.example.com"}, Mismatches: []string{}, UnusedPatterns: []string{}},
+		},
+		{
+			name:     "Migration Leftovers",
+			hosts:    []string{"example-org.mail.protection.outlook.com", "legacy-mx.example.net"},
+			patterns: []string{"*.mail.protection.outlook.com", "aspmx.l.google.com"},
+			want: mtastsbuilder.MXCheckResult{
+				Hosts:          []string{"example-org.mail.protection.outlook.com", "legacy-mx.example.net"},
+				Mismatches:     []string{"legacy-mx.example.net"},
+				UnusedPatterns: []string{"aspmx.l.google.com"},
+			},
+		},
+		{
+			name:      "No Hosts",
+			patterns:  []string{"mx.example.com"},
+			wantField: "Hosts",
+		},
+		{
+			name:      "Invalid Host",
+			hosts:     []string{"mx.example.com", "."},
+			patterns:  []string{"mx.example.com"},
+			wantField: "Hosts[1]",
+		},
+		{
+			name:      "Invalid Pattern",
+			hosts:     []string{"mx.example.com"},
+			patterns:  []string{"mx.*.example.com"},
+			wantField: "MX[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mtastsbuilder.CheckMXHosts(tt.hosts, tt.patterns)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Errorf("CheckMXHosts() error = %v, want field %q", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckMXHosts() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckMXHosts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package mtastsbuilder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+const (
+	// MaxMaxAge is the largest policy max_age allowed by RFC 8461, about a
+	// year.
+	MaxMaxAge = 31557600
+	// DefaultMaxAge is the max_age used when none is set, one week.
+	DefaultMaxAge = 604800
+)
+
+type MTASTSConfig struct {
+	// Mode is the policy mode, "enforce", "testing" or "none".
+	Mode string
+	// MX are the patterns of the MX hosts allowed to receive mail, such as
+	// "*.mail.example.com". They may only be empty in "none" mode.
+	MX []string
+	// MaxAge is the policy lifetime in seconds, DefaultMaxAge when 0.
+	MaxAge int64
+	// Domain is the policy domain, used to return the record name and policy
+	// URL. It is optional.
+	Domain string
+}
+
+// MTASTS is a rendered MTA-STS policy and the TXT record announcing it.
+type MTASTS struct {
+	// Policy is the body of the policy file.
+	Policy string
+	// ID is the policy id, derived from a hash of Policy so that it changes
+	// exactly when the policy does.
+	ID string
+	// Record is the value of the _mta-sts TXT record.
+	Record string
+	// RecordName and PolicyURL are the name of the TXT record and the URL the
+	// policy must be served from, empty when Domain is not set.
+	RecordName string
+	PolicyURL  string
+}
+
+var validModes = map[string]bool{"enforce": true, "testing": true, "none": true}
+
+func MTASTSBuilder(value MTASTSConfig) (MTASTS, error) {
+	if !validModes[value.Mode] {
+		return MTASTS{}, fielderror.Errorf("Mode", "invalid MTA-STS mode %q, must be enforce, testing or none", value.Mode)
+	}
+
+	if value.MaxAge == 0 {
+		value.MaxAge = DefaultMaxAge
+	}
+	if value.MaxAge < 0 || value.MaxAge > MaxMaxAge {
+		return MTASTS{}, fielderror.Errorf("MaxAge", "max_age must be between 1 and %d seconds", MaxMaxAge)
+	}
+
+	if len(value.MX) == 0 && value.Mode != "none" {
+		return MTASTS{}, fielderror.Errorf("MX", "at least one MX pattern is required in %s mode", value.Mode)
+	}
+	seen := map[string]bool{}
+	mx := make([]string, 0, len(value.MX))
+	for i, pattern := range value.MX {
+		pattern, err := normalizePattern(pattern)
+		if err != nil {
+			return MTASTS{}, fielderror.Wrap(fielderror.Index("MX", i), err)
+		}
+		if seen[pattern] {
+			return MTASTS{}, fielderror.Errorf(fielderror.Index("MX", i), "duplicate MX pattern %q", pattern)
+		}
+		seen[pattern] = true
+		mx = append(mx, pattern)
+	}
+
+	lines := []string{"version: STSv1", "mode: " + value.Mode}
+	for _, pattern := range mx {
+		lines = append(lines, "mx: "+pattern)
+	}
+	lines = append(lines, fmt.Sprintf("max_age: %d", value.MaxAge))
+	policy := strings.Join(lines, "\r\n") + "\r\n"
+
+	sum := sha256.Sum256([]byte(policy))
+	id := hex.EncodeToString(sum[:16])
+
+	r := MTASTS{
+		Policy: policy,
+		ID:     id,
+		Record: "v=STSv1; id=" + id,
+	}
+
+	if domain := normalizeHost(value.Domain); domain != "" {
+		if !validate.Hostname(domain) {
+			return MTASTS{}, fielderror.Errorf("Domain", "invalid domain %q", value.Domain)
+		}
+		r.RecordName = "_mta-sts." + domain
+		r.PolicyURL = "https://mta-sts." + domain + "/.well-known/mta-sts.txt"
+	}
+
+	return r, nil
+}
+
+// normalizePattern validates an MX pattern (RFC 8461 section 4.1) and returns
+// it lowercased and without a trailing dot.
+func normalizePattern(pattern string) (string, error) {
+	p := normalizeHost(pattern)
+	host, wildcard := strings.CutPrefix(p, "*.")
+	if strings.Contains(host, "*") {
+		return "", fmt.Errorf("invalid MX pattern %q, a wildcard must be the whole leftmost label", pattern)
+	}
+	if !validate.Hostname(host) {
+		return "", fmt.Errorf("invalid MX pattern %q, must be a host name", pattern)
+	}
+	if wildcard && !strings.Contains(host, ".") {
+		return "", fmt.Errorf("invalid MX pattern %q, a wildcard must be followed by at least two labels", pattern)
+	}
+	return p, nil
+}
+
+func normalizeHost(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
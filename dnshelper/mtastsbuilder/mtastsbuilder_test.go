@@ -0,0 +1,146 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package mtastsbuilder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/mtastsbuilder"
+)
+
+func TestMTASTSBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           mtastsbuilder.MTASTSConfig
+		wantPolicy     string
+		wantRecordName string
+		wantPolicyURL  string
+		wantField      string
+	}{
+		{
+			name:       "Enforce",
+			args:       mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx1.example.com", "*.Mail.Example.com."}, MaxAge: 86400},
+			wantPolicy: "version: STSv1\r\nmode: enforce\r\nmx: mx1.example.com\r\nmx: *.mail.example.com\r\nmax_age: 86400\r\n",
+		},
+		{
+			name:       "Default Max Age",
+			args:       mtastsbuilder.MTASTSConfig{Mode: "testing", MX: []string{"mx.example.com"}},
+			wantPolicy: "version: STSv1\r\nmode: testing\r\nmx: mx.example.com\r\nmax_age: 604800\r\n",
+		},
+		{
+			name:       "None Without MX",
+			args:       mtastsbuilder.MTASTSConfig{Mode: "none", MaxAge: 86400},
+			wantPolicy: "version: STSv1\r\nmode: none\r\nmax_age: 86400\r\n",
+		},
+		{
+			name:           "Domain",
+			args:           mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx.example.com"}, MaxAge: mtastsbuilder.MaxMaxAge, Domain: "Example.com."},
+			wantPolicy:     "version: STSv1\r\nmode: enforce\r\nmx: mx.example.com\r\nmax_age: 31557600\r\n",
+			wantRecordName: "_mta-sts.example.com",
+			wantPolicyURL:  "https://mta-sts.example.com/.well-known/mta-sts.txt",
+		},
+		{
+			name:      "Invalid Mode",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "reject", MX: []string{"mx.example.com"}},
+			wantField: "Mode",
+		},
+		{
+			name:      "Max Age Too Large",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx.example.com"}, MaxAge: mtastsbuilder.MaxMaxAge + 1},
+			wantField: "MaxAge",
+		},
+		{
+			name:      "Negative Max Age",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx.example.com"}, MaxAge: -1},
+			wantField: "MaxAge",
+		},
+		{
+			name:      "Missing MX",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce"},
+			wantField: "MX",
+		},
+		{
+			name:      "Wildcard Not Leftmost",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx.example.com", "mx.*.example.com"}},
+			wantField: "MX[1]",
+		},
+		{
+			name:      "Partial Wildcard",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx*.example.com"}},
+			wantField: "MX[0]",
+		},
+		{
+			name:      "Wildcard Of Top Level Domain",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"*.com"}},
+			wantField: "MX[0]",
+		},
+		{
+			name:      "Duplicate MX",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx.example.com", "MX.example.com."}},
+			wantField: "MX[1]",
+		},
+		{
+			name:      "Invalid Domain",
+			args:      mtastsbuilder.MTASTSConfig{Mode: "enforce", MX: []string{"mx.example.com"}, Domain: "exa mple.com"},
+			wantField: "Domain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mtastsbuilder.MTASTSBuilder(tt.args)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("MTASTSBuilder() error = %v, want field %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MTASTSBuilder() error = %v", err)
+			}
+			if got.Policy != tt.wantPolicy {
+				t.Errorf("MTASTSBuilder() policy = %q, want %q", got.Policy, tt.wantPolicy)
+			}
+			if got.Record != "v=STSv1; id="+got.ID || len(got.ID) != 32 {
+				t.Errorf("MTASTSBuilder() record = %q, id = %q", got.Record, got.ID)
+			}
+			if got.RecordName != tt.wantRecordName || got.PolicyURL != tt.wantPolicyURL {
+				t.Errorf("MTASTSBuilder() = %q, %q, want %q, %q", got.RecordName, got.PolicyURL, tt.wantRecordName, tt.wantPolicyURL)
+			}
+		})
+	}
+}
+
+func TestMTASTSBuilderID(t *testing.T) {
+	config := mtastsbuilder.MTASTSConfig{Mode: "testing", MX: []string{"mx.example.com"}, MaxAge: 86400}
+
+	a, err := mtastsbuilder.MTASTSBuilder(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != "3fc87e4d8bdc5c648937d50a0c4f5eac" {
+		t.Errorf("MTASTSBuilder() id = %s, want 3fc87e4d8bdc5c648937d50a0c4f5eac", a.ID)
+	}
+
+	config.Domain = "example.com"
+	b, err := mtastsbuilder.MTASTSBuilder(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != b.ID {
+		t.Errorf("MTASTSBuilder() id changed with the domain: %s, %s", a.ID, b.ID)
+	}
+
+	config.Mode = "enforce"
+	c, err := mtastsbuilder.MTASTSBuilder(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID == c.ID {
+		t.Errorf("MTASTSBuilder() id did not change with the policy: %s", c.ID)
+	}
+}
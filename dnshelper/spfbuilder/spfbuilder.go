@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/spflib"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
 )
 
 type Resolver interface {
@@ -23,15 +24,15 @@ func BuildSPFRecordWithResolver(domain string, overflow string, txtMaxSize int32
 	spfRecord := strings.Join(parts, " ")
 	rec, err := spflib.Parse(spfRecord, resolver)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse SPF record: %w", err)
+		return nil, fielderror.Wrap("Parts", fmt.Errorf("failed to parse SPF record: %w", err))
 	}
 
 	if txtMaxSize < 1 {
-		return nil, fmt.Errorf("txtMaxSize must be greater than 0")
+		return nil, fielderror.New("TxtMaxSize", "txtMaxSize must be greater than 0")
 	}
 
 	if !strings.Contains(overflow, "%d") {
-		return nil, fmt.Errorf("split format `%s` in `%s` is not proper format (missing `%%d`)", overflow, domain)
+		return nil, fielderror.Errorf("Overflow", "split format `%s` in `%s` is not proper format (missing `%%d`)", overflow, domain)
 	}
 
 	for _, domain := range flatten {
@@ -0,0 +1,105 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sshfpbuilder
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"golang.org/x/crypto/ssh"
+)
+
+// Algorithm numbers of SSHFP records (RFC 4255, RFC 6594, RFC 7479, RFC 8709).
+var algorithms = map[string]int{
+	ssh.KeyAlgoRSA:      1,
+	ssh.KeyAlgoDSA:      2,
+	ssh.KeyAlgoECDSA256: 3,
+	ssh.KeyAlgoECDSA384: 3,
+	ssh.KeyAlgoECDSA521: 3,
+	ssh.KeyAlgoED25519:  4,
+}
+
+// Fingerprint type numbers of SSHFP records.
+var hashTypes = map[string]int{
+	"sha1":   1,
+	"sha256": 2,
+}
+
+// DefaultHashTypes are the fingerprint types published when none are given,
+// matching ssh-keygen -r.
+var DefaultHashTypes = []string{"sha1", "sha256"}
+
+type SSHFPConfig struct {
+	// PublicKeys are OpenSSH public key lines, as found in .pub files.
+	PublicKeys []string
+	// HashTypes are the fingerprint types, "sha1" and "sha256", published for
+	// each key. DefaultHashTypes when empty.
+	HashTypes []string
+}
+
+func SSHFPBuilder(value SSHFPConfig) ([]string, error) {
+	if len(value.PublicKeys) == 0 {
+		return nil, fielderror.New("PublicKeys", "at least one public key is required")
+	}
+
+	if len(value.HashTypes) == 0 {
+		value.HashTypes = DefaultHashTypes
+	}
+	seen := map[string]bool{}
+	for i, h := range value.HashTypes {
+		if _, ok := hashTypes[h]; !ok {
+			return nil, fielderror.Errorf(fielderror.Index("HashTypes", i), "invalid SSHFP fingerprint type %q, must be sha1 or sha256", h)
+		}
+		if seen[h] {
+			return nil, fielderror.Errorf(fielderror.Index("HashTypes", i), "duplicate SSHFP fingerprint type %q", h)
+		}
+		seen[h] = true
+	}
+
+	var records []string
+	for i, line := range value.PublicKeys {
+		algorithm, blob, err := ParsePublicKey(line)
+		if err != nil {
+			return nil, fielderror.Wrap(fielderror.Index("PublicKeys", i), err)
+		}
+		for _, h := range value.HashTypes {
+			records = append(records, fmt.Sprintf("%d %d %s", algorithm, hashTypes[h], fingerprint(h, blob)))
+		}
+	}
+	return records, nil
+}
+
+// ParsePublicKey parses an OpenSSH public key line, returning its SSHFP
+// algorithm number and the key in SSH wire format.
+func ParsePublicKey(line string) (int, []byte, error) {
+	if strings.TrimSpace(line) == "" {
+		return 0, nil, errors.New("public key must not be empty")
+	}
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(line))
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid OpenSSH public key: %w", err)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return 0, nil, errors.New("public key must be a single OpenSSH public key line")
+	}
+	algorithm, ok := algorithms[key.Type()]
+	if !ok {
+		return 0, nil, fmt.Errorf("unsupported SSH key type %q, SSHFP records exist for RSA, DSA, ECDSA and Ed25519 keys", key.Type())
+	}
+	return algorithm, key.Marshal(), nil
+}
+
+func fingerprint(hashType string, blob []byte) string {
+	if hashType == "sha1" {
+		sum := sha1.Sum(blob)
+		return hex.EncodeToString(sum[:])
+	}
+	sum := sha256.Sum256(blob)
+	return hex.EncodeToString(sum[:])
+}
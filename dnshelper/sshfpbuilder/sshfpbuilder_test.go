@@ -0,0 +1,114 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sshfpbuilder_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/sshfpbuilder"
+)
+
+const (
+	ed25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMTgPWPnkCyL6iTW3gZ+DTLBwW48vPLMnsemqrh4hlYl root@host"
+	ecdsaKey   = "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBIAPo+TmvXbmCFMclG7f11kttPB9BrGdGQgICtsvD+eIygu6c0pxN3Zya0/OK8fGz4580XaGFidDoe9cqOz4dNo= root@host"
+	rsaKey     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC3VsF5D5pQJqTTVQ84ajltjzmjpx+hWcrzvtpPSlBx7Mx9ZQ1pxOdVp0+TASCHWm7cYprVW8SjIaXDpMpWrGNUj3Xm3sdPLlmECdO4gkeU+x2BqOkBkbiAQ4blXqcnoPn6+kb6O9lAm6Og5KVvVeTLjekTHByx7L2v6wsQGZuYba9Hc5jo6AtCXetWqmAK+3OtSR0/GRAdsoXbcsIILD3oUCgQ3GrwX7AMROQxpQl7SgzmlrdyD/Jgf3pUxm2Ce0ezm3D3odYvD+lds4A9ev1aBYeVnVwySpiBht5xfI0IA3KcWBQmFZ/1HVHcKtRJGu4E0hXUwcs8XFxGhFiqNXdR root@host"
+	skKey      = "sk-ssh-ed25519@openssh.com AAAAGnNrLXNzaC1lZDI1NTE5QG9wZW5zc2guY29tAAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4fAAAABHNzaDo="
+)
+
+func TestSSHFPBuilder(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      sshfpbuilder.SSHFPConfig
+		want      []string
+		wantField string
+	}{
+		{
+			name: "Ed25519",
+			args: sshfpbuilder.SSHFPConfig{PublicKeys: []string{ed25519Key + "\n"}},
+			want: []string{
+				"4 1 9355da1d6f33d16d6ed89b464658be015233a1d6",
+				"4 2 60ac760bf0a39043fbc028c21f1ab5c09fe48bdd3631a157a0140397f1382e46",
+			},
+		},
+		{
+			name: "All Key Types SHA-256",
+			args: sshfpbuilder.SSHFPConfig{PublicKeys: []string{rsaKey, ecdsaKey, ed25519Key}, HashTypes: []string{"sha256"}},
+			want: []string{
+				"1 2 7959cefc6926fa9c7d0a63e0eeab33f2f10edffbd61679bb7e5fa11e95e73b3e",
+				"3 2 deecdf84b78cc89f411e0a02d599b1db3bf8bd039cea4cd7dc2ee05a30c5c656",
+				"4 2 60ac760bf0a39043fbc028c21f1ab5c09fe48bdd3631a157a0140397f1382e46",
+			},
+		},
+		{
+			name: "Hash Type Order",
+			args: sshfpbuilder.SSHFPConfig{PublicKeys: []string{ecdsaKey}, HashTypes: []string{"sha256", "sha1"}},
+			want: []string{
+				"3 2 deecdf84b78cc89f411e0a02d599b1db3bf8bd039cea4cd7dc2ee05a30c5c656",
+				"3 1 f44452b730c13028d3cab8ea63658f8d52b079f9",
+			},
+		},
+		{
+			name: "Without Comment",
+			args: sshfpbuilder.SSHFPConfig{PublicKeys: []string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC3VsF5D5pQJqTTVQ84ajltjzmjpx+hWcrzvtpPSlBx7Mx9ZQ1pxOdVp0+TASCHWm7cYprVW8SjIaXDpMpWrGNUj3Xm3sdPLlmECdO4gkeU+x2BqOkBkbiAQ4blXqcnoPn6+kb6O9lAm6Og5KVvVeTLjekTHByx7L2v6wsQGZuYba9Hc5jo6AtCXetWqmAK+3OtSR0/GRAdsoXbcsIILD3oUCgQ3GrwX7AMROQxpQl7SgzmlrdyD/Jgf3pUxm2Ce0ezm3D3odYvD+lds4A9ev1aBYeVnVwySpiBht5xfI0IA3KcWBQmFZ/1HVHcKtRJGu4E0hXUwcs8XFxGhFiqNXdR"}, HashTypes: []string{"sha1"}},
+			want: []string{"1 1 2463ba1d67de29db48c356bb89fd3810549fb4c5"},
+		},
+		{
+			name:      "No Keys",
+			args:      sshfpbuilder.SSHFPConfig{},
+			wantField: "PublicKeys",
+		},
+		{
+			name:      "Invalid Hash Type",
+			args:      sshfpbuilder.SSHFPConfig{PublicKeys: []string{ed25519Key}, HashTypes: []string{"sha256", "md5"}},
+			wantField: "HashTypes[1]",
+		},
+		{
+			name:      "Duplicate Hash Type",
+			args:      sshfpbuilder.SSHFPConfig{PublicKeys: []string{ed25519Key}, HashTypes: []string{"sha1", "sha1"}},
+			wantField: "HashTypes[1]",
+		},
+		{
+			name:      "Invalid Key",
+			args:      sshfpbuilder.SSHFPConfig{PublicKeys: []string{ed25519Key, "ssh-ed25519 AAAA"}},
+			wantField: "PublicKeys[1]",
+		},
+		{
+			name:      "Security Key",
+			args:      sshfpbuilder.SSHFPConfig{PublicKeys: []string{skKey}},
+			wantField: "PublicKeys[0]",
+		},
+		{
+			name:      "Several Keys In One Line",
+			args:      sshfpbuilder.SSHFPConfig{PublicKeys: []string{ed25519Key + "\n" + ecdsaKey}},
+			wantField: "PublicKeys[0]",
+		},
+		{
+			name:      "Empty Key",
+			args:      sshfpbuilder.SSHFPConfig{PublicKeys: []string{" "}},
+			wantField: "PublicKeys[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sshfpbuilder.SSHFPBuilder(tt.args)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("SSHFPBuilder() error = %v, want field %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SSHFPBuilder() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SSHFPBuilder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
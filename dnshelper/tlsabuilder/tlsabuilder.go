@@ -0,0 +1,156 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tlsabuilder
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+// Certificate usages (RFC 6698 section 2.1.1, names from RFC 7218).
+const (
+	UsagePKIXTA = 0
+	UsagePKIXEE = 1
+	UsageDANETA = 2
+	UsageDANEEE = 3
+)
+
+// Selectors, the part of the certificate that is matched.
+const (
+	SelectorCert = 0
+	SelectorSPKI = 1
+)
+
+// Matching types, how the selected data is presented.
+const (
+	MatchingFull   = 0
+	MatchingSHA256 = 1
+	MatchingSHA512 = 2
+)
+
+var validProtocols = map[string]bool{"tcp": true, "udp": true, "sctp": true}
+
+type TLSAConfig struct {
+	// Certificate is the PEM certificate, or the PEM public key when Selector
+	// is SelectorSPKI, the record is associated with.
+	Certificate string
+	// Usage is the certificate usage, 0 to 3.
+	Usage int
+	// Selector is 0 to match the full certificate and 1 its public key.
+	Selector int
+	// MatchingType is 0 for the selected data itself, 1 for its SHA-256 and 2
+	// for its SHA-512 hash.
+	MatchingType int
+}
+
+func TLSABuilder(value TLSAConfig) (string, error) {
+	if value.Usage < UsagePKIXTA || value.Usage > UsageDANEEE {
+		return "", fielderror.Errorf("Usage", "invalid TLSA certificate usage %d, must be 0 to 3", value.Usage)
+	}
+	if value.Selector != SelectorCert && value.Selector != SelectorSPKI {
+		return "", fielderror.Errorf("Selector", "invalid TLSA selector %d, must be 0 or 1", value.Selector)
+	}
+
+	data, err := selectData(value.Certificate, value.Selector)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.MatchingType {
+	case MatchingFull:
+	case MatchingSHA256:
+		sum := sha256.Sum256(data)
+		data = sum[:]
+	case MatchingSHA512:
+		sum := sha512.Sum512(data)
+		data = sum[:]
+	default:
+		return "", fielderror.Errorf("MatchingType", "invalid TLSA matching type %d, must be 0, 1 or 2", value.MatchingType)
+	}
+
+	return fmt.Sprintf("%d %d %d %s", value.Usage, value.Selector, value.MatchingType, hex.EncodeToString(data)), nil
+}
+
+// selectData returns the DER data of certificate picked by selector: the
+// certificate itself or its SubjectPublicKeyInfo.
+func selectData(certificate string, selector int) ([]byte, error) {
+	certificate = strings.TrimSpace(certificate)
+	if certificate == "" {
+		return nil, fielderror.New("Certificate", "certificate must not be empty")
+	}
+	block, rest := pem.Decode([]byte(certificate))
+	if block == nil {
+		return nil, fielderror.New("Certificate", "certificate must be PEM encoded")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fielderror.New("Certificate", "PEM must contain a single certificate or public key, not a chain")
+	}
+
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fielderror.Errorf("Certificate", "invalid certificate: %s", err)
+		}
+		if selector == SelectorCert {
+			return cert.Raw, nil
+		}
+		return cert.RawSubjectPublicKeyInfo, nil
+	case "PUBLIC KEY", "RSA PUBLIC KEY":
+		if selector == SelectorCert {
+			return nil, fielderror.New("Selector", "selector 0 matches the full certificate, a public key requires selector 1")
+		}
+		spki, err := parsePublicKey(block)
+		if err != nil {
+			return nil, fielderror.Wrap("Certificate", err)
+		}
+		return spki, nil
+	}
+	if strings.Contains(block.Type, "PRIVATE KEY") {
+		return nil, fielderror.Errorf("Certificate", "PEM block is a %s, the certificate or public key must be used instead", block.Type)
+	}
+	return nil, fielderror.Errorf("Certificate", "unsupported PEM block type %q, must be CERTIFICATE, PUBLIC KEY or RSA PUBLIC KEY", block.Type)
+}
+
+// parsePublicKey returns the DER SubjectPublicKeyInfo of a PEM public key,
+// converting PKCS #1 RSA keys.
+func parsePublicKey(block *pem.Block) ([]byte, error) {
+	if block.Type == "RSA PUBLIC KEY" {
+		k, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA public key: %w", err)
+		}
+		return x509.MarshalPKIXPublicKey(k)
+	}
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return block.Bytes, nil
+}
+
+// RecordName returns the name of the TLSA record of a service,
+// _<port>._<protocol>, followed by host unless it is empty.
+func RecordName(port int, protocol string, host string) (string, error) {
+	if port < 1 || port > 65535 {
+		return "", fielderror.Errorf("Port", "invalid port %d, must be 1 to 65535", port)
+	}
+	protocol = strings.ToLower(protocol)
+	if !validProtocols[protocol] {
+		return "", fielderror.Errorf("Protocol", "invalid protocol %q, must be tcp, udp or sctp", protocol)
+	}
+
+	name, err := validate.RecordName(fmt.Sprintf("_%d._%s", port, protocol), host)
+	if err != nil {
+		return "", fielderror.Wrap("Host", err)
+	}
+	return name, nil
+}
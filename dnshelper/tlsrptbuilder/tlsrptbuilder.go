@@ -0,0 +1,54 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tlsrptbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/validate"
+)
+
+type TLSRPTConfig struct {
+	// Version is the v= tag, "TLSRPTv1" when empty.
+	Version string
+	// RUA are the report destinations, mailto: or https: URIs.
+	RUA []string
+}
+
+func TLSRPTBuilder(value TLSRPTConfig) (string, error) {
+	if value.Version == "" {
+		value.Version = "TLSRPTv1"
+	}
+	if value.Version != "TLSRPTv1" {
+		return "", fielderror.Errorf("Version", "invalid TLS-RPT version %q, must be TLSRPTv1", value.Version)
+	}
+
+	if len(value.RUA) == 0 {
+		return "", fielderror.New("RUA", "at least one report destination is required")
+	}
+	seen := map[string]bool{}
+	for i, uri := range value.RUA {
+		if err := validate.ReportURI(uri); err != nil {
+			return "", fielderror.Wrap(fielderror.Index("RUA", i), err)
+		}
+		if seen[uri] {
+			return "", fielderror.Errorf(fielderror.Index("RUA", i), "duplicate report destination %q", uri)
+		}
+		seen[uri] = true
+	}
+
+	return fmt.Sprintf("v=%s; rua=%s", value.Version, strings.Join(value.RUA, ",")), nil
+}
+
+// RecordName returns the name of the TLS-RPT record, _smtp._tls followed by
+// domain unless it is empty.
+func RecordName(domain string) (string, error) {
+	name, err := validate.RecordName("_smtp._tls", domain)
+	if err != nil {
+		return "", fielderror.Wrap("Domain", err)
+	}
+	return name, nil
+}
@@ -0,0 +1,120 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tlsrptbuilder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/tlsrptbuilder"
+)
+
+func TestTLSRPTBuilder(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      tlsrptbuilder.TLSRPTConfig
+		want      string
+		wantField string
+	}{
+		{
+			name: "Mailto",
+			args: tlsrptbuilder.TLSRPTConfig{RUA: []string{"mailto:tlsrpt@example.com"}},
+			want: "v=TLSRPTv1; rua=mailto:tlsrpt@example.com",
+		},
+		{
+			name: "Mailto And HTTPS",
+			args: tlsrptbuilder.TLSRPTConfig{RUA: []string{"mailto:tls-reports@example.com", "https://reporting.example.com/v1/tlsrpt"}},
+			want: "v=TLSRPTv1; rua=mailto:tls-reports@example.com,https://reporting.example.com/v1/tlsrpt",
+		},
+		{
+			name: "Encoded Address",
+			args: tlsrptbuilder.TLSRPTConfig{Version: "TLSRPTv1", RUA: []string{"mailto:tls%2Brpt@example.com"}},
+			want: "v=TLSRPTv1; rua=mailto:tls%2Brpt@example.com",
+		},
+		{
+			name:      "Invalid Version",
+			args:      tlsrptbuilder.TLSRPTConfig{Version: "TLSRPTv2", RUA: []string{"mailto:tlsrpt@example.com"}},
+			wantField: "Version",
+		},
+		{
+			name:      "No Destinations",
+			args:      tlsrptbuilder.TLSRPTConfig{},
+			wantField: "RUA",
+		},
+		{
+			name:      "HTTP",
+			args:      tlsrptbuilder.TLSRPTConfig{RUA: []string{"mailto:tlsrpt@example.com", "http://reporting.example.com/tlsrpt"}},
+			wantField: "RUA[1]",
+		},
+		{
+			name:      "Bare Address",
+			args:      tlsrptbuilder.TLSRPTConfig{RUA: []string{"tlsrpt@example.com"}},
+			wantField: "RUA[0]",
+		},
+		{
+			name:      "Invalid Address",
+			args:      tlsrptbuilder.TLSRPTConfig{RUA: []string{"mailto:tlsrpt"}},
+			wantField: "RUA[0]",
+		},
+		{
+			name:      "Unencoded Comma",
+			args:      tlsrptbuilder.TLSRPTConfig{RUA: []string{"https://reporting.example.com/a,b"}},
+			wantField: "RUA[0]",
+		},
+		{
+			name:      "Relative URL",
+			args:      tlsrptbuilder.TLSRPTConfig{RUA: []string{"https:/tlsrpt"}},
+			wantField: "RUA[0]",
+		},
+		{
+			name:      "Duplicate",
+			args:      tlsrptbuilder.TLSRPTConfig{RUA: []string{"mailto:tlsrpt@example.com", "mailto:tlsrpt@example.com"}},
+			wantField: "RUA[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tlsrptbuilder.TLSRPTBuilder(tt.args)
+			if tt.wantField != "" {
+				var fe *fielderror.Error
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("TLSRPTBuilder() error = %v, want field %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TLSRPTBuilder() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TLSRPTBuilder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	tests := []struct {
+		domain  string
+		want    string
+		wantErr bool
+	}{
+		{domain: "", want: "_smtp._tls"},
+		{domain: "Example.com.", want: "_smtp._tls.example.com"},
+		{domain: "example..com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got, err := tlsrptbuilder.RecordName(tt.domain)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RecordName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RecordName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
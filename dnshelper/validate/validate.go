@@ -0,0 +1,133 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package validate checks the host names and URIs the builder packages publish
+// in record names and tag values.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// Hostname reports whether name is a host name of dot separated LDH labels,
+// at most 253 characters long and without a trailing dot.
+func Hostname(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RecordName returns the lowercased name of a record under prefix, such as
+// "_smtp._tls", followed by domain unless it is empty.
+func RecordName(prefix string, domain string) (string, error) {
+	name := strings.ToLower(prefix)
+	if domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), ".")); domain != "" {
+		if !Hostname(domain) {
+			return "", fmt.Errorf("invalid domain %q", domain)
+		}
+		name += "." + domain
+	}
+	if len(name) > 253 {
+		return "", fmt.Errorf("record name %q is longer than 253 characters", name)
+	}
+	return name, nil
+}
+
+// ReportURI checks a report destination is a mailto: URI with a single
+// address or an absolute https: URL, as TLS-RPT requires (RFC 8460 section 3).
+func ReportURI(uri string) error {
+	if err := tagValue(uri); err != nil {
+		return err
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid report destination %q: %w", uri, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "mailto":
+		return MailtoURI(uri)
+	case "https":
+		return HTTPSURL(uri)
+	}
+	return fmt.Errorf("invalid report destination %q, must be a mailto: or https: URI", uri)
+}
+
+// MailtoURI checks uri is a mailto: URI with a single email address and no
+// header fields.
+func MailtoURI(uri string) error {
+	if err := tagValue(uri); err != nil {
+		return err
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid report destination %q: %w", uri, err)
+	}
+	if !strings.EqualFold(u.Scheme, "mailto") || u.Opaque == "" || u.RawQuery != "" {
+		return fmt.Errorf("invalid report destination %q, must be mailto:address", uri)
+	}
+
+	address, err := url.PathUnescape(u.Opaque)
+	if err != nil {
+		return fmt.Errorf("invalid report destination %q: %w", uri, err)
+	}
+	if a, err := mail.ParseAddress(address); err != nil || a.Name != "" || a.Address != address {
+		return fmt.Errorf("invalid report destination %q, %q is not an email address", uri, address)
+	}
+	return nil
+}
+
+// HTTPSURL checks uri is an absolute https URL without credentials.
+func HTTPSURL(uri string) error {
+	if err := tagValue(uri); err != nil {
+		return err
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", uri, err)
+	}
+	if !strings.EqualFold(u.Scheme, "https") || u.Host == "" || u.User != nil {
+		return fmt.Errorf("invalid URL %q, must be an absolute https URL without credentials", uri)
+	}
+	return nil
+}
+
+// AbsoluteURI checks uri is an absolute URI that can be published in a tag
+// value.
+func AbsoluteURI(uri string) error {
+	if err := tagValue(uri); err != nil {
+		return err
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid URI %q: %w", uri, err)
+	}
+	if !u.IsAbs() || u.Opaque == "" && u.Host == "" && u.Path == "" {
+		return fmt.Errorf("invalid URI %q, must be an absolute URI such as mailto:address", uri)
+	}
+	return nil
+}
+
+// tagValue checks uri can be published in a tag value, where commas and
+// semicolons separate values and tags, so they must be percent-encoded.
+func tagValue(uri string) error {
+	if strings.ContainsAny(uri, ",; \t") {
+		return fmt.Errorf("invalid URI %q, commas, semicolons and whitespace must be percent-encoded", uri)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+data "dnshelper_dmarc" "example" {
+  domain = "malmeida.dev"
+}
+
+output "dmarc_policy" {
+  value = data.dnshelper_dmarc.example.effective_policy
+}
+
+check "dmarc_enforced" {
+  assert {
+    condition     = data.dnshelper_dmarc.example.effective_policy == "reject"
+    error_message = "DMARC policy of ${data.dnshelper_dmarc.example.domain} is not reject (found at ${coalesce(data.dnshelper_dmarc.example.record_name, "nowhere")})"
+  }
+}
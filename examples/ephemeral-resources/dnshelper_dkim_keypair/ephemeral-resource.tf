@@ -0,0 +1,16 @@
+ephemeral "dnshelper_dkim_keypair" "mail" {
+  key_type = "rsa"
+  rsa_bits = 2048
+  selector = "s202601"
+}
+
+resource "vault_kv_secret_v2" "dkim" {
+  mount = "secret"
+  name  = "mta/dkim/s202601"
+
+  data_json_wo = jsonencode({
+    private_key = ephemeral.dnshelper_dkim_keypair.mail.private_key_pem
+    record      = ephemeral.dnshelper_dkim_keypair.mail.record
+  })
+  data_json_wo_version = 1
+}
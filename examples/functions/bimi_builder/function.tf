@@ -0,0 +1,19 @@
+output "bimi_record" {
+  value = provider::dnshelper::bimi_builder({
+    domain    = "malmeida.dev"
+    location  = "https://malmeida.dev/bimi/logo.svg"
+    authority = "https://malmeida.dev/bimi/vmc.pem"
+    dmarc = provider::dnshelper::dmarc_builder_object({
+      policy = "reject"
+      rua    = ["mailto:dmarc@malmeida.dev"]
+    })
+  })
+}
+
+output "bimi_declined" {
+  value = provider::dnshelper::bimi_builder({
+    selector = "newsletter"
+    domain   = "malmeida.dev"
+    declined = true
+  })
+}
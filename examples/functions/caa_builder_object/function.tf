@@ -0,0 +1,26 @@
+output "caa_records" {
+  value = provider::dnshelper::caa_builder_object({
+    iodef          = ["domain-names@malmeida.dev", "https://iodef.malmeida.dev/report"]
+    iodef_critical = true
+    iodef_mailto   = true
+    issue = [
+      {
+        domain            = "letsencrypt.org"
+        accounturi        = "https://acme-v02.api.letsencrypt.org/acme/acct/1234567890"
+        validationmethods = ["dns-01"]
+        critical          = true
+      },
+      "pki.goog; cansignhttpexchanges=yes",
+    ]
+    issuewild = ["letsencrypt.org"]
+    issuemail = ["none"]
+    issuevmc  = ["digicert.com"]
+
+    contactemail = ["domain-names@malmeida.dev"]
+    contactphone = ["+14155550100"]
+
+    extra = [
+      { tag = "policy", value = "v=1" },
+    ]
+  })
+}
@@ -0,0 +1,7 @@
+terraform {
+  required_providers {
+    dnshelper = {
+      source = "registry.terraform.io/marceloalmeida/dnshelper"
+    }
+  }
+}
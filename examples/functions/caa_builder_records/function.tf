@@ -0,0 +1,20 @@
+locals {
+  caa_records = provider::dnshelper::caa_builder_records({
+    iodef     = "mailto:domain-names@malmeida.dev"
+    issue     = ["letsencrypt.org", "pki.goog; cansignhttpexchanges=yes"]
+    issuewild = ["none"]
+    dedup     = true
+    sort      = true
+  })
+}
+
+# Shaped like the `data` attribute of a Cloudflare CAA record.
+output "cloudflare_caa_data" {
+  value = [
+    for record in local.caa_records : {
+      flags = record.flags
+      tag   = record.tag
+      value = record.value
+    }
+  ]
+}
@@ -0,0 +1,14 @@
+locals {
+  caa = provider::dnshelper::caa_check("*.malmeida.dev", "letsencrypt.org", "", "dns-01")
+}
+
+check "wildcard_certificate" {
+  assert {
+    condition     = local.caa.allowed
+    error_message = "Let's Encrypt may not issue the wildcard certificate: ${local.caa.reason}"
+  }
+}
+
+output "caa_records" {
+  value = local.caa.records
+}
@@ -0,0 +1,17 @@
+locals {
+  caa = provider::dnshelper::caa_parse([
+    "0 issue \"letsencrypt.org; validationmethods=dns-01\"",
+    "0 issuewild \";\"",
+    "0 iodef \"mailto:domain-names@malmeida.dev\"",
+  ])
+}
+
+output "issuers" {
+  value = [for issuer in local.caa.issue : issuer.domain]
+}
+
+output "caa_records" {
+  value = provider::dnshelper::caa_builder_object(merge(local.caa, {
+    issuewild = ["letsencrypt.org"]
+  }))
+}
@@ -0,0 +1,9 @@
+output "dkim_record" {
+  value = provider::dnshelper::dkim_builder({
+    selector        = "s202601"
+    domain          = "malmeida.dev"
+    public_key      = file("${path.module}/dkim.pub")
+    hash_algorithms = ["sha256"]
+    service_types   = ["email"]
+  })
+}
@@ -0,0 +1,11 @@
+locals {
+  dkim = provider::dnshelper::dkim_parse("v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=")
+}
+
+output "dkim_key_type" {
+  value = local.dkim.key_type
+}
+
+output "dkim_key_warnings" {
+  value = local.dkim.warnings
+}
@@ -0,0 +1,19 @@
+locals {
+  dkim = provider::dnshelper::dkim_rotation({
+    selector_format = "s%Y%m"
+    current_date    = "2026-07-01"
+    previous_date   = "2026-01-01"
+    current_key     = file("${path.module}/dkim-202607.pub")
+    previous_key    = file("${path.module}/dkim-202601.pub")
+    phase           = "active"
+    domain          = "malmeida.dev"
+  })
+}
+
+output "dkim_signing_selector" {
+  value = local.dkim.signing_selector
+}
+
+output "dkim_records" {
+  value = { for record in local.dkim.records : record.name => record.chunks }
+}
@@ -0,0 +1,11 @@
+output "dmarc_record" {
+  value = provider::dnshelper::dmarc_builder_object({
+    policy           = "reject"
+    subdomain_policy = "quarantine"
+    alignment_spf    = "relaxed"
+    alignment_dkim   = "strict"
+    rua              = ["mailto:admin@malmeida.dev"]
+    ruf              = ["mailto:alerts@malmeida.dev"]
+    failure_options  = "1"
+  })
+}
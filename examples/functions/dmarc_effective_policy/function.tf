@@ -0,0 +1,11 @@
+locals {
+  dmarc = provider::dnshelper::dmarc_effective_policy("newsletter.malmeida.dev", false)
+}
+
+output "needs_own_dmarc_record" {
+  value = local.dmarc.policy != "reject"
+}
+
+output "dmarc_policy" {
+  value = "${local.dmarc.policy} (${local.dmarc.policy_tag}= at ${local.dmarc.record_name})"
+}
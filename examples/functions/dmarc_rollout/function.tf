@@ -0,0 +1,15 @@
+locals {
+  dmarc_stage = 2
+
+  dmarc = provider::dnshelper::dmarc_rollout("reject", "pct", local.dmarc_stage, {
+    rua = ["mailto:dmarc@malmeida.dev"]
+  })
+}
+
+output "dmarc_record" {
+  value = local.dmarc.record
+}
+
+output "dmarc_rollout_done" {
+  value = local.dmarc.stage == local.dmarc.stage_count - 1
+}
@@ -0,0 +1,15 @@
+locals {
+  dmarc = provider::dnshelper::dmarc_rollout_schedule(
+    "reject",
+    "t",
+    ["2026-01-05", "2026-02-02", "2026-03-02", "2026-04-06"],
+    plantimestamp(),
+    {
+      rua = ["mailto:dmarc@malmeida.dev"]
+    },
+  )
+}
+
+output "dmarc_record" {
+  value = local.dmarc.record
+}
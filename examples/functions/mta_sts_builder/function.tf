@@ -0,0 +1,22 @@
+locals {
+  mta_sts = provider::dnshelper::mta_sts_builder({
+    mode    = "enforce"
+    mx      = ["mx1.malmeida.dev", "*.mail.protection.outlook.com"]
+    max_age = 604800
+    domain  = "malmeida.dev"
+  })
+}
+
+output "mta_sts_record" {
+  value = {
+    name  = local.mta_sts.record_name
+    value = local.mta_sts.record
+  }
+}
+
+output "mta_sts_policy" {
+  value = {
+    url  = local.mta_sts.policy_url
+    body = local.mta_sts.policy
+  }
+}
@@ -0,0 +1,20 @@
+locals {
+  mta_sts_mx = ["*.mail.protection.outlook.com"]
+  mx_check   = provider::dnshelper::mta_sts_mx_check("malmeida.dev", local.mta_sts_mx)
+}
+
+check "mta_sts_mx" {
+  assert {
+    condition     = local.mx_check.valid
+    error_message = "MX hosts not covered by the MTA-STS policy: ${join(", ", local.mx_check.mismatches)}"
+  }
+}
+
+output "mta_sts_unused_patterns" {
+  value = local.mx_check.unused_patterns
+}
+
+# Check MX hosts which are not published yet, without DNS lookups.
+output "mta_sts_planned_mx_check" {
+  value = provider::dnshelper::mta_sts_mx_check("malmeida.dev", local.mta_sts_mx, "malmeida-dev.mail.protection.outlook.com").valid
+}
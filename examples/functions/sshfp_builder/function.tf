@@ -0,0 +1,9 @@
+output "sshfp_records" {
+  value = provider::dnshelper::sshfp_builder({
+    public_keys = [
+      file("${path.module}/ssh_host_ed25519_key.pub"),
+      file("${path.module}/ssh_host_ecdsa_key.pub"),
+    ]
+    hash_types = ["sha256"]
+  })
+}
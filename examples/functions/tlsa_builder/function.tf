@@ -0,0 +1,17 @@
+output "tlsa_record" {
+  value = provider::dnshelper::tlsa_builder({
+    certificate = file("${path.module}/mx.malmeida.dev.pem")
+    host        = "mx.malmeida.dev"
+  })
+}
+
+output "tlsa_https_record" {
+  value = provider::dnshelper::tlsa_builder({
+    certificate   = file("${path.module}/issuing-ca.pem")
+    usage         = 2
+    selector      = 0
+    matching_type = 2
+    port          = 443
+    host          = "www.malmeida.dev"
+  })
+}
@@ -0,0 +1,6 @@
+output "tlsrpt_record" {
+  value = provider::dnshelper::tlsrpt_builder({
+    rua    = ["mailto:tls-reports@malmeida.dev", "https://reporting.malmeida.dev/v1/tlsrpt"]
+    domain = "malmeida.dev"
+  })
+}
@@ -0,0 +1,138 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/bimibuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+var (
+	_ function.Function = BIMIBuilderFunction{}
+)
+
+var bimiBuilderFields = []fieldParameter{
+	{"Selector", "selector"},
+	{"Domain", "domain"},
+	{"Version", "version"},
+	{"Location", "location"},
+	{"Authority", "authority"},
+	{"Declined", "declined"},
+	{"DMARC", "dmarc"},
+}
+
+var bimiBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+}
+
+func NewBIMIBuilderFunction() function.Function {
+	return BIMIBuilderFunction{}
+}
+
+type BIMIBuilderFunction struct{}
+
+func (r BIMIBuilderFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "bimi_builder"
+}
+
+func (r BIMIBuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "BIMI Builder function",
+		MarkdownDescription: "Builds a BIMI record from an object with the attributes `selector`, `domain`, `version`, `location`, `authority`, `declined` and `dmarc`. `location` is the https URL of the SVG logo and `authority` the optional https URL of the Verified Mark Certificate. When `declined` is true the declination record, with empty `l=` and `a=` tags, is built instead and `location` and `authority` must not be set. When `dmarc` is set to the domain's DMARC record, the logo record is only built if the policy is `quarantine` or `reject` without a `pct=` below 100 or testing mode, as BIMI requires. Returns an object with the record `name`, `<selector>._bimi` followed by `domain` when set, where `selector` defaults to `default`, and the `record` value",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the BIMI record attributes",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: bimiBuilderAttributeTypes,
+		},
+	}
+}
+
+func (r BIMIBuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	o, ferr := newObjectArgument(0, value, fieldParameterNames(bimiBuilderFields)...)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	var config bimibuilder.BIMIConfig
+	var selector, domain, dmarc string
+	if selector, ferr = o.String("selector"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if domain, ferr = o.String("domain"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if config.Version, ferr = o.String("version"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if config.Location, ferr = o.String("location"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if config.Authority, ferr = o.String("authority"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if config.Declined, ferr = o.Bool("declined"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if dmarc, ferr = o.String("dmarc"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	if dmarc != "" {
+		policy, err := dmarcbuilder.DmarcParse(dmarc)
+		if err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, fielderror.Errorf("DMARC", "invalid DMARC record: %s", err), bimiBuilderFields))
+			return
+		}
+		config.DMARC = &policy
+	}
+
+	name, err := bimibuilder.RecordName(selector, domain)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, bimiBuilderFields))
+		return
+	}
+
+	record, err := bimibuilder.BIMIBuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, bimiBuilderFields))
+		return
+	}
+
+	result := struct {
+		Name   string `tfsdk:"name"`
+		Record string `tfsdk:"record"`
+	}{
+		Name:   name,
+		Record: record,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
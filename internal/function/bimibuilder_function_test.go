@@ -0,0 +1,168 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var bimiBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+}
+
+func TestBIMIBuilderFunction_Metadata(t *testing.T) {
+	f := tffunction.NewBIMIBuilderFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "bimi_builder", resp.Name)
+}
+
+func TestBIMIBuilderFunction_Definition(t *testing.T) {
+	f := tffunction.NewBIMIBuilderFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "BIMI Builder function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: bimiBuilderAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestBIMIBuilderFunction_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]attr.Value
+		wantName   string
+		wantRecord string
+		wantErr    string
+	}{
+		{
+			name: "default selector",
+			attrs: map[string]attr.Value{
+				"location":  types.StringValue("https://example.com/bimi/logo.svg"),
+				"authority": types.StringValue("https://example.com/bimi/vmc.pem"),
+			},
+			wantName:   "default._bimi",
+			wantRecord: "v=BIMI1; l=https://example.com/bimi/logo.svg; a=https://example.com/bimi/vmc.pem",
+		},
+		{
+			name: "selector and domain",
+			attrs: map[string]attr.Value{
+				"selector": types.StringValue("brand"),
+				"domain":   types.StringValue("example.com"),
+				"location": types.StringValue("https://example.com/bimi/brand.svg"),
+				"dmarc":    types.StringValue("v=DMARC1; p=reject; rua=mailto:dmarc@example.com"),
+			},
+			wantName:   "brand._bimi.example.com",
+			wantRecord: "v=BIMI1; l=https://example.com/bimi/brand.svg",
+		},
+		{
+			name: "declined",
+			attrs: map[string]attr.Value{
+				"domain":   types.StringValue("example.com"),
+				"declined": types.BoolValue(true),
+			},
+			wantName:   "default._bimi.example.com",
+			wantRecord: "v=BIMI1; l=; a=",
+		},
+		{
+			name: "not svg",
+			attrs: map[string]attr.Value{
+				"location": types.StringValue("https://example.com/bimi/logo.png"),
+			},
+			wantErr: `attribute "location": invalid logo URL "https://example.com/bimi/logo.png", must point to an .svg file`,
+		},
+		{
+			name: "dmarc not enforcing",
+			attrs: map[string]attr.Value{
+				"location": types.StringValue("https://example.com/bimi/logo.svg"),
+				"dmarc":    types.StringValue("v=DMARC1; p=quarantine; pct=25"),
+			},
+			wantErr: `attribute "dmarc": DMARC policy applies to 25% of messages, BIMI requires pct=100`,
+		},
+		{
+			name: "invalid dmarc",
+			attrs: map[string]attr.Value{
+				"location": types.StringValue("https://example.com/bimi/logo.svg"),
+				"dmarc":    types.StringValue("v=spf1 -all"),
+			},
+			wantErr: `attribute "dmarc": invalid DMARC record: not a DMARC record, must start with v=DMARC1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewBIMIBuilderFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(bimiBuilderAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(bimiBuilderAttributeTypes, map[string]attr.Value{
+				"name":   types.StringValue(tt.wantName),
+				"record": types.StringValue(tt.wantRecord),
+			})
+			require.Equal(t, function.NewResultData(want), resp.Result)
+		})
+	}
+}
+
+func TestAccBIMIBuilderFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output_jsonencode" {
+  value = jsonencode(provider::dnshelper::bimi_builder({
+    domain   = "malmeida.dev"
+    location = "https://malmeida.dev/bimi/logo.svg"
+    dmarc    = "v=DMARC1; p=reject"
+  }))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output_jsonencode",
+							`{"name":"default._bimi.malmeida.dev","record":"v=BIMI1; l=https://malmeida.dev/bimi/logo.svg"}`,
+						),
+					),
+				},
+			},
+		},
+	)
+}
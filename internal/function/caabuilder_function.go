@@ -5,17 +5,29 @@ package function
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
 )
 
 var (
 	_ function.Function = CAABuilderFunction{}
 )
 
+// caaBuilderFields maps CAAConfig fields to caa_builder parameters, in
+// parameter order.
+var caaBuilderFields = []fieldParameter{
+	{"Iodef", "iodef"},
+	{"IodefCritical", "iodef_critical"},
+	{"Issue", "issue"},
+	{"IssueCritical", "issue_critical"},
+	{"Issuewild", "issuewild"},
+	{"IssuewildCritical", "issuewild_critical"},
+}
+
 func NewCAABuilderFunction() function.Function {
 	return CAABuilderFunction{}
 }
@@ -31,9 +43,9 @@ func (r CAABuilderFunction) Definition(_ context.Context, _ function.DefinitionR
 		Summary:             "CAA Builder function",
 		MarkdownDescription: "Builds a CAA records",
 		Parameters: []function.Parameter{
-			function.StringParameter{
+			function.DynamicParameter{
 				Name:                "iodef",
-				MarkdownDescription: "The contact mail address",
+				MarkdownDescription: "The URL, or list of URLs, violations are reported to. Each must be a `mailto:`, `http:` or `https:` URL and is published as its own iodef record. Bare email addresses are prefixed with `mailto:`",
 			},
 			function.BoolParameter{
 				Name:                "iodef_critical",
@@ -42,7 +54,7 @@ func (r CAABuilderFunction) Definition(_ context.Context, _ function.DefinitionR
 			function.ListParameter{
 				ElementType:         types.StringType,
 				Name:                "issue",
-				MarkdownDescription: "List of CAs which are allowed to issue certificates for the domain",
+				MarkdownDescription: "List of CAs which are allowed to issue certificates for the domain. Entries may carry parameters such as the RFC 8657 `accounturi` and `validationmethods`, e.g. `letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01`. Aliases of well-known CAs such as `letsencrypt` are replaced by the CA issuer domain name, other issuer domain names are used as given; use `caa_builder_object` with `strict_issuers` to reject issuer domain names missing from the registry of well-known CAs",
 			},
 			function.BoolParameter{
 				Name:                "issue_critical",
@@ -51,7 +63,7 @@ func (r CAABuilderFunction) Definition(_ context.Context, _ function.DefinitionR
 			function.ListParameter{
 				ElementType:         types.StringType,
 				Name:                "issuewild",
-				MarkdownDescription: "Allowed CAs which can issue wildcard certificates for this domain",
+				MarkdownDescription: "Allowed CAs which can issue wildcard certificates for this domain. Entries take the same parameters as `issue`",
 			},
 			function.BoolParameter{
 				Name:                "issuewild_critical",
@@ -66,32 +78,49 @@ func (r CAABuilderFunction) Definition(_ context.Context, _ function.DefinitionR
 
 func (r CAABuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
 	var data struct {
-		Iodef             string   `tfsdk:"iodef"`
-		IodefCritical     bool     `tfsdk:"iodef_critical"`
-		Issue             []string `tfsdk:"issue"`
-		IssueCritical     bool     `tfsdk:"issue_critical"`
-		Issuewild         []string `tfsdk:"issuewild"`
-		IssuewildCritical bool     `tfsdk:"issuewild_critical"`
+		Iodef             attr.Value `tfsdk:"iodef"`
+		IodefCritical     bool       `tfsdk:"iodef_critical"`
+		Issue             []string   `tfsdk:"issue"`
+		IssueCritical     bool       `tfsdk:"issue_critical"`
+		Issuewild         []string   `tfsdk:"issuewild"`
+		IssuewildCritical bool       `tfsdk:"issuewild_critical"`
 	}
 
 	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data.Iodef, &data.IodefCritical, &data.Issue, &data.IssueCritical, &data.Issuewild, &data.IssuewildCritical))
 
 	if resp.Error != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Errorf("failed to get arguments").Error()))
+		return
+	}
+
+	iodef, ok := stringOrList(data.Iodef)
+	if !ok {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, "iodef must be a string or a list of strings"))
+		return
+	}
+
+	issue, err := caabuilder.ParseIssuers(data.Issue)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(fielderror.Wrap("Issue", err), caaBuilderFields))
+		return
+	}
+	issuewild, err := caabuilder.ParseIssuers(data.Issuewild)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(fielderror.Wrap("Issuewild", err), caaBuilderFields))
 		return
 	}
 
 	config := caabuilder.CAAConfig{
-		Iodef:             data.Iodef,
+		Iodef:             iodef,
 		IodefCritical:     data.IodefCritical,
-		Issue:             data.Issue,
+		IodefMailto:       true,
+		Issue:             issue,
 		IssueCritical:     data.IssueCritical,
-		Issuewild:         data.Issuewild,
+		Issuewild:         issuewild,
 		IssuewildCritical: data.IssuewildCritical,
 	}
 	result, err := caabuilder.CAABuilderString(config)
 	if err != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, caaBuilderFields))
 		return
 	}
 
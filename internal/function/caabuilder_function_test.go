@@ -64,7 +64,7 @@ func TestCaaBuilderFunction_Fail_Run(t *testing.T) {
 			wantErr: true,
 			wantResp: &function.RunResponse{
 				Result: function.NewResultData(types.ListValueMust(types.StringType, []attr.Value{})),
-				Error:  function.NewFuncError("CAABuilder requires at least one entry in issue or issuewild"),
+				Error:  function.NewFuncError("CAABuilder requires at least one entry in issue, issuewild, issuemail or issuevmc"),
 			},
 		},
 	}
@@ -150,6 +150,46 @@ func TestCaaBuilderFunction_Run(t *testing.T) {
 				Error: nil,
 			},
 		},
+		{
+			name: "issuer parameters",
+			args: map[string]interface{}{
+				"iodef":              "mailto:domain-names@malmeida.dev",
+				"iodef_critical":     false,
+				"issue":              []string{"letsencrypt.org;accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234;validationmethods=dns-01,http-01"},
+				"issue_critical":     false,
+				"issuewild":          []string{"none"},
+				"issuewild_critical": false,
+			},
+			wantErr: false,
+			wantResp: &function.RunResponse{
+				Result: function.NewResultData(types.ListValueMust(types.StringType, []attr.Value{
+					types.StringValue("0 iodef \"mailto:domain-names@malmeida.dev\""),
+					types.StringValue("0 issue \"letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01,http-01\""),
+					types.StringValue("0 issuewild \";\""),
+				})),
+				Error: nil,
+			},
+		},
+		{
+			name: "issuer outside the registry",
+			args: map[string]interface{}{
+				"iodef":              "mailto:domain-names@malmeida.dev",
+				"iodef_critical":     false,
+				"issue":              []string{"telia.com", "letsencrypt"},
+				"issue_critical":     false,
+				"issuewild":          []string{},
+				"issuewild_critical": false,
+			},
+			wantErr: false,
+			wantResp: &function.RunResponse{
+				Result: function.NewResultData(types.ListValueMust(types.StringType, []attr.Value{
+					types.StringValue("0 iodef \"mailto:domain-names@malmeida.dev\""),
+					types.StringValue("0 issue \"telia.com\""),
+					types.StringValue("0 issue \"letsencrypt.org\""),
+				})),
+				Error: nil,
+			},
+		},
 		{
 			name: "invalid input",
 			args: map[string]interface{}{
@@ -163,7 +203,7 @@ func TestCaaBuilderFunction_Run(t *testing.T) {
 			wantErr: true,
 			wantResp: &function.RunResponse{
 				Result: function.NewResultData(types.ListValueMust(types.StringType, []attr.Value{})),
-				Error:  function.NewFuncError("CAABuilder requires at least one entry in issue or issuewild"),
+				Error:  function.NewFuncError("CAABuilder requires at least one entry in issue, issuewild, issuemail or issuevmc"),
 			},
 		},
 	}
@@ -214,6 +254,32 @@ func TestCaaBuilderFunction_Run(t *testing.T) {
 	}
 }
 
+func TestCaaBuilderFunction_Run_IodefMailto(t *testing.T) {
+	f := tffunction.NewCAABuilderFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.ListValueMust(types.StringType, sliceToValues([]string{"security@example.com", "https://example.com/caa"})),
+			types.BoolValue(false),
+			types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+			types.BoolValue(false),
+			types.ListValueMust(types.StringType, []attr.Value{}),
+			types.BoolValue(false),
+		}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ListUnknown(types.StringType)),
+	}
+	f.Run(context.Background(), req, resp)
+
+	require.Nil(t, resp.Error)
+	require.Equal(t, function.NewResultData(types.ListValueMust(types.StringType, sliceToValues([]string{
+		`0 iodef "mailto:security@example.com"`,
+		`0 iodef "https://example.com/caa"`,
+		`0 issue "letsencrypt.org"`,
+	}))), resp.Result)
+}
+
 func TestAccCaaBuilderFunction_tf(t *testing.T) {
 	t.Setenv("TF_ACC", "1")
 
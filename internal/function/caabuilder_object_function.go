@@ -0,0 +1,292 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+var (
+	_ function.Function = CAABuilderObjectFunction{}
+)
+
+// caaBuilderObjectFields extends the caa_builder parameters with the
+// attributes only available in the object form.
+var caaBuilderObjectFields = append(caaBuilderFields[:len(caaBuilderFields):len(caaBuilderFields)],
+	fieldParameter{"Issuemail", "issuemail"},
+	fieldParameter{"IssuemailCritical", "issuemail_critical"},
+	fieldParameter{"Issuevmc", "issuevmc"},
+	fieldParameter{"IssuevmcCritical", "issuevmc_critical"},
+	fieldParameter{"Contactemail", "contactemail"},
+	fieldParameter{"Contactphone", "contactphone"},
+	fieldParameter{"IodefMailto", "iodef_mailto"},
+	fieldParameter{"StrictIssuers", "strict_issuers"},
+	fieldParameter{"Dedup", "dedup"},
+	fieldParameter{"Sort", "sort"},
+	fieldParameter{"Extra", "extra"},
+)
+
+func NewCAABuilderObjectFunction() function.Function {
+	return CAABuilderObjectFunction{}
+}
+
+type CAABuilderObjectFunction struct{}
+
+func (r CAABuilderObjectFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "caa_builder_object"
+}
+
+func (r CAABuilderObjectFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "CAA Builder function with object argument",
+		MarkdownDescription: "Builds CAA records from an object with optional attributes: `iodef`, `iodef_critical`, `iodef_mailto`, `issue`, `issue_critical`, `issuewild`, `issuewild_critical`, `issuemail`, `issuemail_critical`, `issuevmc`, `issuevmc_critical`, `contactemail`, `contactphone`, `extra`, `strict_issuers`, `dedup` and `sort`. Attributes have the same meaning as the `caa_builder` parameters of the same name. Setting `iodef_mailto` prefixes bare email addresses in `iodef` with `mailto:`. `issuemail` lists the CAs allowed to issue S/MIME certificates (RFC 9495) and `issuevmc` the CAs allowed to issue BIMI Verified Mark Certificates, each with its own critical flag; a single `none` entry forbids issuance. `contactemail` and `contactphone` list the RFC 5322 email addresses and E.164 phone numbers CAs may use to contact the domain owner for validation. Entries of `issue`, `issuewild`, `issuemail` and `issuevmc` are either issuer strings or objects with a `domain` and optional `accounturi`, `validationmethods` (list) and `parameters` (map) attributes, and a `critical` flag overriding the critical flag of the tag for that entry. `extra` lists records with tags the builder has no attribute for, such as experimental CA properties, as objects with a `tag` of up to 15 letters and digits, a `value` and an optional `flag` of 0 or 128. Issuer domain names are checked against a registry of well-known CAs: aliases such as `letsencrypt`, `digicert` or `amazon` are replaced by the CA issuer domain name, and issuer domain names missing from the registry, as used by private CAs, are accepted unless `strict_issuers` is set. Records are returned in input order unless `sort` is set, which orders them by tag and then value; `dedup` drops repeated records after issuer values are normalized",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the CAA record attributes, all of which are optional",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (r CAABuilderObjectFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, ferr := caaConfigFromObject(0, value)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	result, err := caabuilder.CAABuilderString(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, caaBuilderObjectFields))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+func caaConfigFromObject(index int64, value types.Dynamic) (caabuilder.CAAConfig, *function.FuncError) {
+	var config caabuilder.CAAConfig
+
+	o, ferr := newObjectArgument(index, value, fieldParameterNames(caaBuilderObjectFields)...)
+	if ferr != nil {
+		return config, ferr
+	}
+
+	if config.Iodef, ferr = o.StringOrList("iodef"); ferr != nil {
+		return config, ferr
+	}
+	if config.IodefMailto, ferr = o.Bool("iodef_mailto"); ferr != nil {
+		return config, ferr
+	}
+	if config.IodefCritical, ferr = o.Bool("iodef_critical"); ferr != nil {
+		return config, ferr
+	}
+
+	issuers := []struct {
+		tag      string
+		values   *[]caabuilder.Issuer
+		critical *bool
+	}{
+		{"issue", &config.Issue, &config.IssueCritical},
+		{"issuewild", &config.Issuewild, &config.IssuewildCritical},
+		{"issuemail", &config.Issuemail, &config.IssuemailCritical},
+		{"issuevmc", &config.Issuevmc, &config.IssuevmcCritical},
+	}
+	for _, issuer := range issuers {
+		var entryCritical []*bool
+		if *issuer.values, entryCritical, ferr = caaIssuerList(o, issuer.tag); ferr != nil {
+			return config, ferr
+		}
+		if *issuer.critical, ferr = o.Bool(issuer.tag + "_critical"); ferr != nil {
+			return config, ferr
+		}
+		if flags := caaEntryCritical(entryCritical, *issuer.critical); flags != nil {
+			if config.EntryCritical == nil {
+				config.EntryCritical = map[string][]bool{}
+			}
+			config.EntryCritical[issuer.tag] = flags
+		}
+	}
+
+	if config.Contactemail, ferr = o.StringList("contactemail"); ferr != nil {
+		return config, ferr
+	}
+	if config.Contactphone, ferr = o.StringList("contactphone"); ferr != nil {
+		return config, ferr
+	}
+	if config.Extra, ferr = caaExtraList(o); ferr != nil {
+		return config, ferr
+	}
+	if config.StrictIssuers, ferr = o.Bool("strict_issuers"); ferr != nil {
+		return config, ferr
+	}
+	if config.Dedup, ferr = o.Bool("dedup"); ferr != nil {
+		return config, ferr
+	}
+	if config.Sort, ferr = o.Bool("sort"); ferr != nil {
+		return config, ferr
+	}
+
+	return config, nil
+}
+
+// caaEntryCritical returns the per-entry critical flags of a tag, with entries
+// that do not set their own using the flag of the tag, or nil when no entry
+// sets its own.
+func caaEntryCritical(entries []*bool, critical bool) []bool {
+	var r []bool
+	for i, c := range entries {
+		if c == nil {
+			continue
+		}
+		if r == nil {
+			r = make([]bool, len(entries))
+			for j := range r {
+				r[j] = critical
+			}
+		}
+		r[i] = *c
+	}
+	return r
+}
+
+// caaExtraList decodes the extra attribute, a list of objects with the flag,
+// tag and value of records the builder has no attribute for.
+func caaExtraList(o *objectArgument) ([]caabuilder.CAARecord, *function.FuncError) {
+	elements, ferr := o.elements("extra")
+	if ferr != nil || elements == nil {
+		return nil, ferr
+	}
+
+	r := make([]caabuilder.CAARecord, 0, len(elements))
+	for i, e := range elements {
+		path := fmt.Sprintf("extra[%d]", i)
+		if d, ok := e.(basetypes.DynamicValue); ok {
+			e = d.UnderlyingValue()
+		}
+		switch e.(type) {
+		case basetypes.ObjectValue, basetypes.MapValue:
+		default:
+			return nil, o.errorf(path, "must be an object")
+		}
+		entry, ferr := decodeObject(o.index, o.attributePath(path), e, "flag", "tag", "value")
+		if ferr != nil {
+			return nil, ferr
+		}
+
+		var record caabuilder.CAARecord
+		flag, ferr := entry.Int32("flag")
+		if ferr != nil {
+			return nil, ferr
+		}
+		record.Flag = int(flag)
+		if record.Tag, ferr = entry.String("tag"); ferr != nil {
+			return nil, ferr
+		}
+		if record.Value, ferr = entry.String("value"); ferr != nil {
+			return nil, ferr
+		}
+		r = append(r, record)
+	}
+	return r, nil
+}
+
+// caaIssuerList decodes an issuer attribute of issuer strings or objects,
+// returning the critical flag of each entry, nil when not set.
+func caaIssuerList(o *objectArgument, name string) ([]caabuilder.Issuer, []*bool, *function.FuncError) {
+	elements, ferr := o.elements(name)
+	if ferr != nil || elements == nil {
+		return nil, nil, ferr
+	}
+
+	r := make([]caabuilder.Issuer, 0, len(elements))
+	critical := make([]*bool, len(elements))
+	for i, e := range elements {
+		path := fmt.Sprintf("%s[%d]", name, i)
+		if d, ok := e.(basetypes.DynamicValue); ok {
+			e = d.UnderlyingValue()
+		}
+		if s, ok := e.(basetypes.StringValue); ok && !s.IsNull() {
+			if s.ValueString() == "none" {
+				if len(elements) > 1 {
+					return nil, nil, o.errorf(path, `"none" must be the only entry`)
+				}
+				r = append(r, caabuilder.Issuer{})
+				continue
+			}
+			issuer, err := caabuilder.ParseIssuer(s.ValueString())
+			if err != nil {
+				return nil, nil, o.errorf(path, "%s", err)
+			}
+			r = append(r, issuer)
+			continue
+		}
+
+		switch e.(type) {
+		case basetypes.ObjectValue, basetypes.MapValue:
+		default:
+			return nil, nil, o.errorf(path, "must be a string or an object")
+		}
+		entry, ferr := decodeObject(o.index, o.attributePath(path), e, "domain", "accounturi", "validationmethods", "parameters", "critical")
+		if ferr != nil {
+			return nil, nil, ferr
+		}
+
+		var issuer caabuilder.Issuer
+		if issuer.Domain, ferr = entry.String("domain"); ferr != nil {
+			return nil, nil, ferr
+		}
+		if issuer.AccountURI, ferr = entry.String("accounturi"); ferr != nil {
+			return nil, nil, ferr
+		}
+		if issuer.ValidationMethods, ferr = entry.StringList("validationmethods"); ferr != nil {
+			return nil, nil, ferr
+		}
+		parameters, ferr := entry.StringMap("parameters")
+		if ferr != nil {
+			return nil, nil, ferr
+		}
+		tags := make([]string, 0, len(parameters))
+		for tag := range parameters {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		for _, tag := range tags {
+			issuer.Parameters = append(issuer.Parameters, caabuilder.IssuerParameter{Tag: tag, Value: parameters[tag]})
+		}
+
+		if entry.lookup("critical") != nil {
+			c, ferr := entry.Bool("critical")
+			if ferr != nil {
+				return nil, nil, ferr
+			}
+			critical[i] = &c
+		}
+
+		if err := issuer.Validate(); err != nil {
+			return nil, nil, o.errorf(path, "%s", err)
+		}
+		r = append(r, issuer)
+	}
+	return r, critical, nil
+}
@@ -0,0 +1,406 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+func TestCaaBuilderObjectFunction_Metadata(t *testing.T) {
+	f := tffunction.NewCAABuilderObjectFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "caa_builder_object", resp.Name)
+}
+
+func TestCaaBuilderObjectFunction_Definition(t *testing.T) {
+	f := tffunction.NewCAABuilderObjectFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "CAA Builder function with object argument", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+}
+
+func TestCaaBuilderObjectFunction_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]attr.Value
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "issue only",
+			attrs: map[string]attr.Value{
+				"issue": types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("letsencrypt.org")}),
+			},
+			want: []string{`0 issue "letsencrypt.org"`},
+		},
+		{
+			name: "all attributes",
+			attrs: map[string]attr.Value{
+				"iodef":              types.StringValue("mailto:security@example.com"),
+				"iodef_critical":     types.BoolValue(true),
+				"issue":              types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"issuewild":          types.ListValueMust(types.StringType, sliceToValues([]string{"sectigo.com"})),
+				"issuewild_critical": types.BoolValue(true),
+			},
+			want: []string{
+				`128 iodef "mailto:security@example.com"`,
+				`0 issue "letsencrypt.org"`,
+				`128 issuewild "sectigo.com"`,
+			},
+		},
+		{
+			name: "wrong attribute type",
+			attrs: map[string]attr.Value{
+				"issue":          types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"issue_critical": types.StringValue("yes"),
+			},
+			wantErr: `attribute "issue_critical": must be a bool`,
+		},
+		{
+			name: "issue is not a list",
+			attrs: map[string]attr.Value{
+				"issue": types.StringValue("letsencrypt.org"),
+			},
+			wantErr: `attribute "issue": must be a list`,
+		},
+		{
+			name: "structured issuers",
+			attrs: map[string]attr.Value{
+				"issue": types.TupleValueMust(
+					[]attr.Type{
+						types.ObjectType{AttrTypes: map[string]attr.Type{
+							"domain":            types.StringType,
+							"accounturi":        types.StringType,
+							"validationmethods": types.TupleType{ElemTypes: []attr.Type{types.StringType}},
+						}},
+						types.StringType,
+					},
+					[]attr.Value{
+						types.ObjectValueMust(
+							map[string]attr.Type{
+								"domain":            types.StringType,
+								"accounturi":        types.StringType,
+								"validationmethods": types.TupleType{ElemTypes: []attr.Type{types.StringType}},
+							},
+							map[string]attr.Value{
+								"domain":            types.StringValue("letsencrypt.org"),
+								"accounturi":        types.StringValue("https://acme-v02.api.letsencrypt.org/acme/acct/1234"),
+								"validationmethods": types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("dns-01")}),
+							},
+						),
+						types.StringValue("pki.goog; cansignhttpexchanges=yes"),
+					},
+				),
+				"issuewild": types.TupleValueMust(
+					[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{
+						"domain":     types.StringType,
+						"parameters": types.ObjectType{AttrTypes: map[string]attr.Type{"cansignhttpexchanges": types.StringType}},
+					}}},
+					[]attr.Value{types.ObjectValueMust(
+						map[string]attr.Type{
+							"domain":     types.StringType,
+							"parameters": types.ObjectType{AttrTypes: map[string]attr.Type{"cansignhttpexchanges": types.StringType}},
+						},
+						map[string]attr.Value{
+							"domain":     types.StringValue("digicert.com"),
+							"parameters": types.ObjectValueMust(map[string]attr.Type{"cansignhttpexchanges": types.StringType}, map[string]attr.Value{"cansignhttpexchanges": types.StringValue("yes")}),
+						},
+					)},
+				),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01"`,
+				`0 issue "pki.goog; cansignhttpexchanges=yes"`,
+				`0 issuewild "digicert.com; cansignhttpexchanges=yes"`,
+			},
+		},
+		{
+			name: "invalid structured issuer",
+			attrs: map[string]attr.Value{
+				"issue": types.TupleValueMust(
+					[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"domain": types.StringType, "accounturi": types.StringType}}},
+					[]attr.Value{types.ObjectValueMust(
+						map[string]attr.Type{"domain": types.StringType, "accounturi": types.StringType},
+						map[string]attr.Value{"domain": types.StringValue("letsencrypt.org"), "accounturi": types.StringValue("acct/1234")},
+					)},
+				),
+			},
+			wantErr: `attribute "issue[0]": invalid accounturi "acct/1234", must be an absolute URI`,
+		},
+		{
+			name: "unsupported issuer attribute",
+			attrs: map[string]attr.Value{
+				"issue": types.TupleValueMust(
+					[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"ca": types.StringType}}},
+					[]attr.Value{types.ObjectValueMust(map[string]attr.Type{"ca": types.StringType}, map[string]attr.Value{"ca": types.StringValue("letsencrypt.org")})},
+				),
+			},
+			wantErr: `attribute "issue[0].ca": unsupported attribute`,
+		},
+		{
+			name: "mail and vmc issuers",
+			attrs: map[string]attr.Value{
+				"issue":              types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"issuemail":          types.ListValueMust(types.StringType, sliceToValues([]string{"none"})),
+				"issuevmc":           types.ListValueMust(types.StringType, sliceToValues([]string{"digicert.com"})),
+				"issuevmc_critical":  types.BoolValue(true),
+				"issuemail_critical": types.BoolValue(false),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 issuemail ";"`,
+				`128 issuevmc "digicert.com"`,
+			},
+		},
+		{
+			name: "invalid vmc issuer",
+			attrs: map[string]attr.Value{
+				"issue":    types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"issuevmc": types.ListValueMust(types.StringType, sliceToValues([]string{"digicert.com", "none"})),
+			},
+			wantErr: `attribute "issuevmc[1]": "none" must be the only entry`,
+		},
+		{
+			name: "contacts",
+			attrs: map[string]attr.Value{
+				"issue":        types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"contactemail": types.ListValueMust(types.StringType, sliceToValues([]string{"security@example.com"})),
+				"contactphone": types.ListValueMust(types.StringType, sliceToValues([]string{"+14155550100"})),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 contactemail "security@example.com"`,
+				`0 contactphone "+14155550100"`,
+			},
+		},
+		{
+			name: "invalid contact phone",
+			attrs: map[string]attr.Value{
+				"issue":        types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"contactphone": types.ListValueMust(types.StringType, sliceToValues([]string{"+14155550100", "0800 123"})),
+			},
+			wantErr: `attribute "contactphone[1]": invalid contact phone "0800 123"`,
+		},
+		{
+			name: "iodef list with mailto prefix",
+			attrs: map[string]attr.Value{
+				"iodef":        types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("security@example.com"), types.StringValue("https://iodef.example.com/")}),
+				"iodef_mailto": types.BoolValue(true),
+				"issue":        types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+			},
+			want: []string{
+				`0 iodef "mailto:security@example.com"`,
+				`0 iodef "https://iodef.example.com/"`,
+				`0 issue "letsencrypt.org"`,
+			},
+		},
+		{
+			name: "invalid iodef",
+			attrs: map[string]attr.Value{
+				"iodef": types.StringValue("security@example.com"),
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+			},
+			wantErr: `attribute "iodef[0]": invalid iodef URL "security@example.com", email addresses must be prefixed with mailto:`,
+		},
+		{
+			name: "invalid issuer string",
+			attrs: map[string]attr.Value{
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org", "sectigo.com; validationmethods=dns_01"})),
+			},
+			wantErr: `attribute "issue[1]": invalid validation method "dns_01"`,
+		},
+		{
+			name: "issuer alias",
+			attrs: map[string]attr.Value{
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt", "pki.goog"})),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 issue "pki.goog"`,
+			},
+		},
+		{
+			name: "issuemail only",
+			attrs: map[string]attr.Value{
+				"issuemail": types.ListValueMust(types.StringType, sliceToValues([]string{"sectigo.com"})),
+			},
+			want: []string{`0 issuemail "sectigo.com"`},
+		},
+		{
+			name: "unknown issuer",
+			attrs: map[string]attr.Value{
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"ca.example.net"})),
+			},
+			want: []string{`0 issue "ca.example.net"`},
+		},
+		{
+			name: "unknown issuer strict",
+			attrs: map[string]attr.Value{
+				"issue":          types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org", "ca.example.net"})),
+				"strict_issuers": types.BoolValue(true),
+			},
+			wantErr: `attribute "issue[1]": unknown CA issuer domain "ca.example.net"`,
+		},
+		{
+			name: "sort and dedup",
+			attrs: map[string]attr.Value{
+				"issue":     types.ListValueMust(types.StringType, sliceToValues([]string{"sectigo.com", "letsencrypt.org", "sectigo.com"})),
+				"issuewild": types.ListValueMust(types.StringType, sliceToValues([]string{"sectigo.com"})),
+				"iodef":     types.StringValue("mailto:security@example.com"),
+				"dedup":     types.BoolValue(true),
+				"sort":      types.BoolValue(true),
+			},
+			want: []string{
+				`0 iodef "mailto:security@example.com"`,
+				`0 issue "letsencrypt.org"`,
+				`0 issue "sectigo.com"`,
+				`0 issuewild "sectigo.com"`,
+			},
+		},
+		{
+			name: "entry critical",
+			attrs: map[string]attr.Value{
+				"issue": types.TupleValueMust(
+					[]attr.Type{
+						types.StringType,
+						types.ObjectType{AttrTypes: map[string]attr.Type{"domain": types.StringType, "critical": types.BoolType}},
+					},
+					[]attr.Value{
+						types.StringValue("letsencrypt.org"),
+						types.ObjectValueMust(
+							map[string]attr.Type{"domain": types.StringType, "critical": types.BoolType},
+							map[string]attr.Value{"domain": types.StringValue("pki.goog"), "critical": types.BoolValue(false)},
+						),
+					},
+				),
+				"issue_critical": types.BoolValue(true),
+			},
+			want: []string{
+				`128 issue "letsencrypt.org"`,
+				`0 issue "pki.goog"`,
+			},
+		},
+		{
+			name: "extra",
+			attrs: map[string]attr.Value{
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"extra": types.TupleValueMust(
+					[]attr.Type{
+						types.ObjectType{AttrTypes: map[string]attr.Type{"tag": types.StringType, "value": types.StringType}},
+						types.ObjectType{AttrTypes: map[string]attr.Type{"flag": types.NumberType, "tag": types.StringType, "value": types.StringType}},
+					},
+					[]attr.Value{
+						types.ObjectValueMust(
+							map[string]attr.Type{"tag": types.StringType, "value": types.StringType},
+							map[string]attr.Value{"tag": types.StringValue("policy"), "value": types.StringValue("v=1")},
+						),
+						types.ObjectValueMust(
+							map[string]attr.Type{"flag": types.NumberType, "tag": types.StringType, "value": types.StringType},
+							map[string]attr.Value{"flag": types.NumberValue(big.NewFloat(128)), "tag": types.StringValue("tbs"), "value": types.StringValue("unknown")},
+						),
+					},
+				),
+			},
+			want: []string{
+				`0 issue "letsencrypt.org"`,
+				`0 policy "v=1"`,
+				`128 tbs "unknown"`,
+			},
+		},
+		{
+			name: "invalid extra tag",
+			attrs: map[string]attr.Value{
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				"extra": types.TupleValueMust(
+					[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"tag": types.StringType, "value": types.StringType}}},
+					[]attr.Value{types.ObjectValueMust(
+						map[string]attr.Type{"tag": types.StringType, "value": types.StringType},
+						map[string]attr.Value{"tag": types.StringValue("x-policy"), "value": types.StringValue("v=1")},
+					)},
+				),
+			},
+			wantErr: `attribute "extra[0]": invalid CAA tag "x-policy", must be 1 to 15 letters and digits`,
+		},
+		{
+			name:    "no issuers",
+			attrs:   map[string]attr.Value{},
+			wantErr: "CAABuilder requires at least one entry in issue, issuewild, issuemail or issuevmc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewCAABuilderObjectFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.ListValueMust(types.StringType, sliceToValues(tt.want))), resp.Result)
+		})
+	}
+}
+
+func TestAccCaaBuilderObjectFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output_jsonencode" {
+  value = jsonencode(provider::dnshelper::caa_builder_object({
+    iodef     = "mailto:domain-names@malmeida.dev"
+    issue     = ["letsencrypt.org"]
+    issuewild = ["sectigo.com"]
+  }))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output_jsonencode",
+							"[\"0 iodef \\\"mailto:domain-names@malmeida.dev\\\"\",\"0 issue \\\"letsencrypt.org\\\"\",\"0 issuewild \\\"sectigo.com\\\"\"]",
+						),
+					),
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,88 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+var (
+	_ function.Function = CAABuilderRecordsFunction{}
+)
+
+var caaRecordAttributeTypes = map[string]attr.Type{
+	"flags": types.Int64Type,
+	"tag":   types.StringType,
+	"value": types.StringType,
+}
+
+type caaRecordModel struct {
+	Flags int64  `tfsdk:"flags"`
+	Tag   string `tfsdk:"tag"`
+	Value string `tfsdk:"value"`
+}
+
+func NewCAABuilderRecordsFunction() function.Function {
+	return CAABuilderRecordsFunction{}
+}
+
+type CAABuilderRecordsFunction struct{}
+
+func (r CAABuilderRecordsFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "caa_builder_records"
+}
+
+func (r CAABuilderRecordsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "CAA Builder function with structured records",
+		MarkdownDescription: "Builds CAA records from the same object as `caa_builder_object`, returning each record as an object with its `flags`, `tag` and unquoted `value`, as expected by DNS provider resources that take the CAA fields separately",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the CAA record attributes, all of which are optional",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: caaRecordAttributeTypes},
+		},
+	}
+}
+
+func (r CAABuilderRecordsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, ferr := caaConfigFromObject(0, value)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	records, err := caabuilder.CAABuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, caaBuilderObjectFields))
+		return
+	}
+
+	result := make([]caaRecordModel, 0, len(records))
+	for _, record := range records {
+		result = append(result, caaRecordModel{
+			Flags: int64(record.Flag),
+			Tag:   record.Tag,
+			Value: record.Value,
+		})
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
@@ -0,0 +1,147 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var caaRecordAttributeTypes = map[string]attr.Type{
+	"flags": types.Int64Type,
+	"tag":   types.StringType,
+	"value": types.StringType,
+}
+
+func caaRecordValue(flags int64, tag, value string) attr.Value {
+	return types.ObjectValueMust(caaRecordAttributeTypes, map[string]attr.Value{
+		"flags": types.Int64Value(flags),
+		"tag":   types.StringValue(tag),
+		"value": types.StringValue(value),
+	})
+}
+
+func TestCaaBuilderRecordsFunction_Metadata(t *testing.T) {
+	f := tffunction.NewCAABuilderRecordsFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "caa_builder_records", resp.Name)
+}
+
+func TestCaaBuilderRecordsFunction_Definition(t *testing.T) {
+	f := tffunction.NewCAABuilderRecordsFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "CAA Builder function with structured records", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ListType{ElemType: types.ObjectType{AttrTypes: caaRecordAttributeTypes}}, resp.Definition.Return.GetType())
+}
+
+func TestCaaBuilderRecordsFunction_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]attr.Value
+		want    []attr.Value
+		wantErr string
+	}{
+		{
+			name: "records",
+			attrs: map[string]attr.Value{
+				"iodef":          types.StringValue("mailto:security@example.com"),
+				"iodef_critical": types.BoolValue(true),
+				"issue":          types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org", "pki.goog; cansignhttpexchanges=yes"})),
+				"issuewild":      types.ListValueMust(types.StringType, sliceToValues([]string{"none"})),
+			},
+			want: []attr.Value{
+				caaRecordValue(128, "iodef", "mailto:security@example.com"),
+				caaRecordValue(0, "issue", "letsencrypt.org"),
+				caaRecordValue(0, "issue", "pki.goog; cansignhttpexchanges=yes"),
+				caaRecordValue(0, "issuewild", ";"),
+			},
+		},
+		{
+			name: "invalid issuer",
+			attrs: map[string]attr.Value{
+				"issue": types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org", "sectigo.com; validationmethods=dns_01"})),
+			},
+			wantErr: `attribute "issue[1]": invalid validation method "dns_01"`,
+		},
+		{
+			name:    "no issuers",
+			attrs:   map[string]attr.Value{},
+			wantErr: "CAABuilder requires at least one entry in issue, issuewild, issuemail or issuevmc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewCAABuilderRecordsFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.ObjectType{AttrTypes: caaRecordAttributeTypes})),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.ListValueMust(types.ObjectType{AttrTypes: caaRecordAttributeTypes}, tt.want)), resp.Result)
+		})
+	}
+}
+
+func TestAccCaaBuilderRecordsFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output_jsonencode" {
+  value = jsonencode(provider::dnshelper::caa_builder_records({
+    iodef          = "mailto:domain-names@malmeida.dev"
+    iodef_critical = true
+    issue          = ["letsencrypt.org"]
+  }))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output_jsonencode",
+							`[{"flags":128,"tag":"iodef","value":"mailto:domain-names@malmeida.dev"},{"flags":0,"tag":"issue","value":"letsencrypt.org"}]`,
+						),
+					),
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,108 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+var (
+	_ function.Function = CAACheckFunction{}
+)
+
+var caaCheckFields = []fieldParameter{
+	{"Hostname", "hostname"},
+	{"Issuer", "issuer"},
+	{"AccountURI", "account_uri"},
+	{"ValidationMethod", "validation_method"},
+}
+
+var caaCheckAttributeTypes = map[string]attr.Type{
+	"allowed":     types.BoolType,
+	"reason":      types.StringType,
+	"tag":         types.StringType,
+	"record_name": types.StringType,
+	"alias":       types.StringType,
+	"records":     types.ListType{ElemType: types.StringType},
+}
+
+func NewCAACheckFunction() function.Function {
+	return CAACheckFunction{}
+}
+
+type CAACheckFunction struct{}
+
+func (r CAACheckFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "caa_check"
+}
+
+func (r CAACheckFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "CAA check function",
+		MarkdownDescription: "Evaluates whether a CA may issue a certificate for a hostname following RFC 8659. The relevant CAA record set is looked up at the hostname and then at each of its parents, following CNAME records, and the `issue` records (or `issuewild` records for a `*.` hostname, when published) are matched against the CA, including the RFC 8657 `accounturi` and `validationmethods` parameters. A critical record with an unknown tag forbids issuance. Returns an object with `allowed`, a human readable `reason`, the `tag` that decided the outcome, the `record_name` the records were found at, the `alias` they were read from when `record_name` is a CNAME, and the `records` themselves; `tag`, `record_name` and `alias` are empty when not applicable",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "hostname",
+				MarkdownDescription: "The hostname the certificate is requested for, prefixed with `*.` for a wildcard certificate",
+			},
+			function.StringParameter{
+				Name:                "issuer",
+				MarkdownDescription: "The issuer domain name of the CA, e.g. `letsencrypt.org`. Well-known CAs may also be given by alias, such as `letsencrypt`, and are authorized by any of their issuer domain names",
+			},
+			function.StringParameter{
+				Name:                "account_uri",
+				MarkdownDescription: "The ACME account URI of the request, matched against `accounturi` parameters. Empty when not known",
+			},
+			function.StringParameter{
+				Name:                "validation_method",
+				MarkdownDescription: "The validation method of the request, e.g. `dns-01`, matched against `validationmethods` parameters. Empty when not known",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: caaCheckAttributeTypes,
+		},
+	}
+}
+
+func (r CAACheckFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var request caabuilder.CheckRequest
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &request.Hostname, &request.Issuer, &request.AccountURI, &request.ValidationMethod))
+
+	if resp.Error != nil {
+		return
+	}
+
+	check, err := caabuilder.Check(request, newResolver())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, caaCheckFields))
+		return
+	}
+
+	result := struct {
+		Allowed    bool     `tfsdk:"allowed"`
+		Reason     string   `tfsdk:"reason"`
+		Tag        string   `tfsdk:"tag"`
+		RecordName string   `tfsdk:"record_name"`
+		Alias      string   `tfsdk:"alias"`
+		Records    []string `tfsdk:"records"`
+	}{
+		Allowed:    check.Allowed,
+		Reason:     check.Reason,
+		Tag:        check.Tag,
+		RecordName: check.RecordName,
+		Alias:      check.Alias,
+		Records:    []string{},
+	}
+	for _, record := range check.Records {
+		result.Records = append(result.Records, record.String())
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
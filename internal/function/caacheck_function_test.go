@@ -0,0 +1,168 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var caaCheckAttributeTypes = map[string]attr.Type{
+	"allowed":     types.BoolType,
+	"reason":      types.StringType,
+	"tag":         types.StringType,
+	"record_name": types.StringType,
+	"alias":       types.StringType,
+	"records":     types.ListType{ElemType: types.StringType},
+}
+
+func TestCaaCheckFunction_Metadata(t *testing.T) {
+	f := tffunction.NewCAACheckFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "caa_check", resp.Name)
+}
+
+func TestCaaCheckFunction_Definition(t *testing.T) {
+	f := tffunction.NewCAACheckFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "CAA check function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 4)
+	require.Equal(t, "hostname", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, "issuer", resp.Definition.Parameters[1].GetName())
+	require.Equal(t, "account_uri", resp.Definition.Parameters[2].GetName())
+	require.Equal(t, "validation_method", resp.Definition.Parameters[3].GetName())
+	require.Equal(t, types.ObjectType{AttrTypes: caaCheckAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestCaaCheckFunction_Run(t *testing.T) {
+	exampleCom := []string{
+		`0 issue "letsencrypt.org"`,
+		`0 issue "pki.goog; accounturi=https://dv.acme-v02.api.pki.goog/account/1; validationmethods=dns-01"`,
+		`0 issuewild ";"`,
+		`0 iodef "mailto:security@example.com"`,
+	}
+
+	tests := []struct {
+		name             string
+		hostname         string
+		issuer           string
+		accountURI       string
+		validationMethod string
+		wantAllowed      bool
+		wantReason       string
+		wantTag          string
+		wantRecordName   string
+		wantAlias        string
+		wantRecords      []string
+		wantArgument     *int64
+	}{
+		{
+			name:           "allowed by parent",
+			hostname:       "www.example.com",
+			issuer:         "letsencrypt.org",
+			wantAllowed:    true,
+			wantReason:     "issue record at example.com authorizes letsencrypt.org",
+			wantTag:        "issue",
+			wantRecordName: "example.com",
+			wantRecords:    exampleCom,
+		},
+		{
+			name:           "wildcard denied",
+			hostname:       "*.example.com",
+			issuer:         "letsencrypt.org",
+			wantReason:     "no issuewild record at example.com authorizes letsencrypt.org",
+			wantTag:        "issuewild",
+			wantRecordName: "example.com",
+			wantRecords:    exampleCom,
+		},
+		{
+			name:             "account and validation method",
+			hostname:         "example.com",
+			issuer:           "pki.goog",
+			accountURI:       "https://dv.acme-v02.api.pki.goog/account/1",
+			validationMethod: "http-01",
+			wantReason:       "no issue record at example.com authorizes pki.goog with the requested account and validation method",
+			wantTag:          "issue",
+			wantRecordName:   "example.com",
+			wantRecords:      exampleCom,
+		},
+		{
+			name:           "cname",
+			hostname:       "www.example.org",
+			issuer:         "digicert.com",
+			wantAllowed:    true,
+			wantReason:     "issue record at www.example.org authorizes digicert.com",
+			wantTag:        "issue",
+			wantRecordName: "www.example.org",
+			wantAlias:      "cdn.example.net",
+			wantRecords:    []string{`0 issue "digicert.com"`},
+		},
+		{
+			name:           "critical unknown tag",
+			hostname:       "strict.example.com",
+			issuer:         "letsencrypt.org",
+			wantReason:     `critical CAA record with unknown tag "tbs" at strict.example.com`,
+			wantRecordName: "strict.example.com",
+			wantRecords:    []string{`0 issue "letsencrypt.org"`, `128 tbs "unknown"`},
+		},
+		{
+			name:        "no records",
+			hostname:    "example.edu",
+			issuer:      "letsencrypt.org",
+			wantAllowed: true,
+			wantReason:  "no CAA records are published for the hostname or its parents",
+			wantRecords: []string{},
+		},
+		{
+			name:         "invalid issuer",
+			hostname:     "example.com",
+			issuer:       "letsencrypt.org/acme",
+			wantArgument: func() *int64 { i := int64(1); return &i }(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewCAACheckFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.hostname),
+					types.StringValue(tt.issuer),
+					types.StringValue(tt.accountURI),
+					types.StringValue(tt.validationMethod),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(caaCheckAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantArgument != nil {
+				require.NotNil(t, resp.Error)
+				require.Equal(t, tt.wantArgument, resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.ObjectValueMust(caaCheckAttributeTypes, map[string]attr.Value{
+				"allowed":     types.BoolValue(tt.wantAllowed),
+				"reason":      types.StringValue(tt.wantReason),
+				"tag":         types.StringValue(tt.wantTag),
+				"record_name": types.StringValue(tt.wantRecordName),
+				"alias":       types.StringValue(tt.wantAlias),
+				"records":     types.ListValueMust(types.StringType, sliceToValues(tt.wantRecords)),
+			})), resp.Result)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/caabuilder"
+)
+
+var (
+	_ function.Function = CAAParseFunction{}
+)
+
+var caaParseFields = []fieldParameter{
+	{"Records", "records"},
+}
+
+var caaIssuerAttributeTypes = map[string]attr.Type{
+	"domain":            types.StringType,
+	"accounturi":        types.StringType,
+	"validationmethods": types.ListType{ElemType: types.StringType},
+	"parameters":        types.MapType{ElemType: types.StringType},
+	"critical":          types.BoolType,
+}
+
+var caaExtraAttributeTypes = map[string]attr.Type{
+	"flag":  types.Int64Type,
+	"tag":   types.StringType,
+	"value": types.StringType,
+}
+
+var caaParseAttributeTypes = map[string]attr.Type{
+	"iodef":              types.ListType{ElemType: types.StringType},
+	"iodef_critical":     types.BoolType,
+	"issue":              types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issue_critical":     types.BoolType,
+	"issuewild":          types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issuewild_critical": types.BoolType,
+	"issuemail":          types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issuemail_critical": types.BoolType,
+	"issuevmc":           types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issuevmc_critical":  types.BoolType,
+	"contactemail":       types.ListType{ElemType: types.StringType},
+	"contactphone":       types.ListType{ElemType: types.StringType},
+	"extra":              types.ListType{ElemType: types.ObjectType{AttrTypes: caaExtraAttributeTypes}},
+}
+
+type caaIssuerModel struct {
+	Domain            string            `tfsdk:"domain"`
+	AccountURI        *string           `tfsdk:"accounturi"`
+	ValidationMethods []string          `tfsdk:"validationmethods"`
+	Parameters        map[string]string `tfsdk:"parameters"`
+	Critical          bool              `tfsdk:"critical"`
+}
+
+type caaExtraModel struct {
+	Flag  int64  `tfsdk:"flag"`
+	Tag   string `tfsdk:"tag"`
+	Value string `tfsdk:"value"`
+}
+
+func NewCAAParseFunction() function.Function {
+	return CAAParseFunction{}
+}
+
+type CAAParseFunction struct{}
+
+func (r CAAParseFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "caa_parse"
+}
+
+func (r CAAParseFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "CAA Parse function",
+		MarkdownDescription: "Parses a CAA record set into an object with the attributes accepted by `caa_builder_object`. Issuer entries are returned as objects with the CA `domain` and its `accounturi`, `validationmethods` and other `parameters`, which are null when not set. Each issuer object also has its own `critical` flag, which differs between entries of a tag when its records disagree. Records with tags `caa_builder_object` has no attribute for are returned in `extra` as objects with their `flag`, `tag` and `value`. Tags without records are returned as empty lists",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				ElementType:         types.StringType,
+				Name:                "records",
+				MarkdownDescription: "The CAA records, each in presentation format such as `0 issue \"letsencrypt.org\"` or in the RFC 3597 generic form `\\# length hex`",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: caaParseAttributeTypes,
+		},
+	}
+}
+
+func (r CAAParseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var records []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &records))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, err := caabuilder.CAAParse(records)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, caaParseFields))
+		return
+	}
+
+	result := struct {
+		Iodef             []string         `tfsdk:"iodef"`
+		IodefCritical     bool             `tfsdk:"iodef_critical"`
+		Issue             []caaIssuerModel `tfsdk:"issue"`
+		IssueCritical     bool             `tfsdk:"issue_critical"`
+		Issuewild         []caaIssuerModel `tfsdk:"issuewild"`
+		IssuewildCritical bool             `tfsdk:"issuewild_critical"`
+		Issuemail         []caaIssuerModel `tfsdk:"issuemail"`
+		IssuemailCritical bool             `tfsdk:"issuemail_critical"`
+		Issuevmc          []caaIssuerModel `tfsdk:"issuevmc"`
+		IssuevmcCritical  bool             `tfsdk:"issuevmc_critical"`
+		Contactemail      []string         `tfsdk:"contactemail"`
+		Contactphone      []string         `tfsdk:"contactphone"`
+		Extra             []caaExtraModel  `tfsdk:"extra"`
+	}{
+		Iodef:             nonNil(config.Iodef),
+		IodefCritical:     config.IodefCritical,
+		Issue:             caaIssuerModels(config.Issue, config.EntryCritical["issue"], config.IssueCritical),
+		IssueCritical:     config.IssueCritical,
+		Issuewild:         caaIssuerModels(config.Issuewild, config.EntryCritical["issuewild"], config.IssuewildCritical),
+		IssuewildCritical: config.IssuewildCritical,
+		Issuemail:         caaIssuerModels(config.Issuemail, config.EntryCritical["issuemail"], config.IssuemailCritical),
+		IssuemailCritical: config.IssuemailCritical,
+		Issuevmc:          caaIssuerModels(config.Issuevmc, config.EntryCritical["issuevmc"], config.IssuevmcCritical),
+		IssuevmcCritical:  config.IssuevmcCritical,
+		Contactemail:      nonNil(config.Contactemail),
+		Contactphone:      nonNil(config.Contactphone),
+		Extra:             []caaExtraModel{},
+	}
+	for _, record := range config.Extra {
+		result.Extra = append(result.Extra, caaExtraModel{Flag: int64(record.Flag), Tag: record.Tag, Value: record.Value})
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+// caaIssuerModels converts the issuers returned by CAAParse into their parts,
+// with the per-entry critical flags when the records of the tag disagree and
+// the flag of the tag otherwise.
+func caaIssuerModels(issuers []caabuilder.Issuer, entryCritical []bool, critical bool) []caaIssuerModel {
+	r := make([]caaIssuerModel, 0, len(issuers))
+	for i, issuer := range issuers {
+		m := caaIssuerModel{
+			Domain:            issuer.Domain,
+			ValidationMethods: issuer.ValidationMethods,
+			Critical:          critical,
+		}
+		if entryCritical != nil {
+			m.Critical = entryCritical[i]
+		}
+		if issuer.AccountURI != "" {
+			m.AccountURI = &issuer.AccountURI
+		}
+		if len(issuer.Parameters) > 0 {
+			m.Parameters = make(map[string]string, len(issuer.Parameters))
+			for _, p := range issuer.Parameters {
+				m.Parameters[p.Tag] = p.Value
+			}
+		}
+		r = append(r, m)
+	}
+	return r
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,189 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var caaIssuerAttributeTypes = map[string]attr.Type{
+	"domain":            types.StringType,
+	"accounturi":        types.StringType,
+	"validationmethods": types.ListType{ElemType: types.StringType},
+	"parameters":        types.MapType{ElemType: types.StringType},
+	"critical":          types.BoolType,
+}
+
+var caaExtraAttributeTypes = map[string]attr.Type{
+	"flag":  types.Int64Type,
+	"tag":   types.StringType,
+	"value": types.StringType,
+}
+
+var caaParseAttributeTypes = map[string]attr.Type{
+	"iodef":              types.ListType{ElemType: types.StringType},
+	"iodef_critical":     types.BoolType,
+	"issue":              types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issue_critical":     types.BoolType,
+	"issuewild":          types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issuewild_critical": types.BoolType,
+	"issuemail":          types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issuemail_critical": types.BoolType,
+	"issuevmc":           types.ListType{ElemType: types.ObjectType{AttrTypes: caaIssuerAttributeTypes}},
+	"issuevmc_critical":  types.BoolType,
+	"contactemail":       types.ListType{ElemType: types.StringType},
+	"contactphone":       types.ListType{ElemType: types.StringType},
+	"extra":              types.ListType{ElemType: types.ObjectType{AttrTypes: caaExtraAttributeTypes}},
+}
+
+func TestCaaParseFunction_Metadata(t *testing.T) {
+	f := tffunction.NewCAAParseFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "caa_parse", resp.Name)
+}
+
+func TestCaaParseFunction_Definition(t *testing.T) {
+	f := tffunction.NewCAAParseFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "CAA Parse function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "records", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.ListType{ElemType: types.StringType}, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: caaParseAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestCaaParseFunction_Run(t *testing.T) {
+	records := []string{
+		`128 iodef "mailto:security@example.com"`,
+		`0 issue "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01"`,
+		`\# 19 00056973737565 706b692e676f6f67`,
+		`0 issuewild ";"`,
+		`0 contactphone "+14155550100"`,
+		`128 issue "sectigo.com"`,
+		`0 tbs "unknown"`,
+	}
+
+	f := tffunction.NewCAAParseFunction()
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(caaParseAttributeTypes)),
+	}
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(types.StringType, sliceToValues(records))}),
+	}, resp)
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Text, "records[2]: generic CAA record length is 19 but has 15 bytes of data")
+	require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+
+	records[2] = `\# 15 00056973737565 706b692e676f6f67`
+	resp.Error = nil
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(types.StringType, sliceToValues(records))}),
+	}, resp)
+	require.Nil(t, resp.Error)
+
+	got, ok := resp.Result.Value().(types.Object)
+	require.True(t, ok)
+	attrs := got.Attributes()
+	require.Equal(t, types.ListValueMust(types.StringType, sliceToValues([]string{"mailto:security@example.com"})), attrs["iodef"])
+	require.Equal(t, types.BoolValue(true), attrs["iodef_critical"])
+	require.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}), attrs["contactemail"])
+
+	require.Equal(t, types.ListValueMust(types.ObjectType{AttrTypes: caaExtraAttributeTypes}, []attr.Value{
+		types.ObjectValueMust(caaExtraAttributeTypes, map[string]attr.Value{
+			"flag":  types.Int64Value(0),
+			"tag":   types.StringValue("tbs"),
+			"value": types.StringValue("unknown"),
+		}),
+	}), attrs["extra"])
+
+	issue := attrs["issue"].(types.List).Elements()
+	require.Len(t, issue, 3)
+	first := issue[0].(types.Object).Attributes()
+	require.Equal(t, types.StringValue("letsencrypt.org"), first["domain"])
+	require.Equal(t, types.StringValue("https://acme-v02.api.letsencrypt.org/acme/acct/1234"), first["accounturi"])
+	require.Equal(t, types.ListValueMust(types.StringType, sliceToValues([]string{"dns-01"})), first["validationmethods"])
+	require.True(t, first["parameters"].IsNull())
+	second := issue[1].(types.Object).Attributes()
+	require.Equal(t, types.StringValue("pki.goog"), second["domain"])
+	require.True(t, second["accounturi"].IsNull())
+	require.Equal(t, types.BoolValue(false), second["critical"])
+	third := issue[2].(types.Object).Attributes()
+	require.Equal(t, types.StringValue("sectigo.com"), third["domain"])
+	require.Equal(t, types.BoolValue(true), third["critical"])
+	require.Equal(t, types.BoolValue(false), attrs["issue_critical"])
+
+	// The parsed object is accepted by caa_builder_object and rebuilds the
+	// record set.
+	built := &function.RunResponse{
+		Result: function.NewResultData(types.ListUnknown(types.StringType)),
+	}
+	tffunction.NewCAABuilderObjectFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(got)}),
+	}, built)
+	require.Nil(t, built.Error)
+	require.Equal(t, function.NewResultData(types.ListValueMust(types.StringType, sliceToValues([]string{
+		`128 iodef "mailto:security@example.com"`,
+		`0 issue "letsencrypt.org; accounturi=https://acme-v02.api.letsencrypt.org/acme/acct/1234; validationmethods=dns-01"`,
+		`0 issue "pki.goog"`,
+		`128 issue "sectigo.com"`,
+		`0 issuewild ";"`,
+		`0 contactphone "+14155550100"`,
+		`0 tbs "unknown"`,
+	}))), built.Result)
+}
+
+func TestAccCaaParseFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+locals {
+  caa = provider::dnshelper::caa_parse([
+    "0 issue \"letsencrypt.org; validationmethods=dns-01\"",
+    "0 issuewild \";\"",
+  ])
+}
+
+output "issuer" {
+  value = local.caa.issue[0].domain
+}
+
+output "roundtrip_jsonencode" {
+  value = jsonencode(provider::dnshelper::caa_builder_object(local.caa))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("issuer", "letsencrypt.org"),
+						resource.TestCheckOutput(
+							"roundtrip_jsonencode",
+							"[\"0 issue \\\"letsencrypt.org; validationmethods=dns-01\\\"\",\"0 issuewild \\\";\\\"\"]",
+						),
+					),
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,146 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+)
+
+var (
+	_ function.Function = DKIMBuilderFunction{}
+)
+
+var dkimBuilderFields = []fieldParameter{
+	{"Selector", "selector"},
+	{"Domain", "domain"},
+	{"Version", "version"},
+	{"KeyType", "key_type"},
+	{"PublicKey", "public_key"},
+	{"HashAlgorithms", "hash_algorithms"},
+	{"ServiceTypes", "service_types"},
+	{"Flags", "flags"},
+	{"Notes", "notes"},
+}
+
+var dkimBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+	"chunks": types.ListType{ElemType: types.StringType},
+}
+
+func NewDKIMBuilderFunction() function.Function {
+	return DKIMBuilderFunction{}
+}
+
+type DKIMBuilderFunction struct{}
+
+func (r DKIMBuilderFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dkim_builder"
+}
+
+func (r DKIMBuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DKIM Builder function",
+		MarkdownDescription: "Builds a DKIM key record from an object with the attributes `selector` (required), `domain`, `public_key`, `key_type`, `version`, `hash_algorithms`, `service_types`, `flags` and `notes`. `public_key` is an RSA or Ed25519 public key, PEM or base64 encoded, which is parsed to validate it and published without the PEM armour; when it is empty the record revokes the selector (p=). `key_type` (k=) defaults to the type of `public_key`, `hash_algorithms` (h=) only accepts `sha256` as `sha1` is forbidden by RFC 8301, `service_types` (s=) accepts `*` and `email`, `flags` (t=) accepts `y` and `s` and `notes` (n=) is quoted-printable encoded. Returns an object with the record `name` (`<selector>._domainkey`, followed by `domain` when set), the `record` value and the value split into 255 byte `chunks` for DNS providers that require TXT strings to be split",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the DKIM record attributes",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dkimBuilderAttributeTypes,
+		},
+	}
+}
+
+func (r DKIMBuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	o, ferr := newObjectArgument(0, value, fieldParameterNames(dkimBuilderFields)...)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	var selector, domain string
+	if selector, ferr = o.String("selector"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if domain, ferr = o.String("domain"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	config, ferr := dkimConfigFromObject(o)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	name, err := dkimbuilder.RecordName(selector, domain)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, dkimBuilderFields))
+		return
+	}
+
+	record, err := dkimbuilder.DKIMBuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, dkimBuilderFields))
+		return
+	}
+
+	result := struct {
+		Name   string   `tfsdk:"name"`
+		Record string   `tfsdk:"record"`
+		Chunks []string `tfsdk:"chunks"`
+	}{
+		Name:   name,
+		Record: record,
+		Chunks: dkimbuilder.SplitTXT(record, dkimbuilder.TXTStringSize),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+func dkimConfigFromObject(o *objectArgument) (dkimbuilder.DKIMConfig, *function.FuncError) {
+	var config dkimbuilder.DKIMConfig
+	var ferr *function.FuncError
+
+	if config.Version, ferr = o.String("version"); ferr != nil {
+		return config, ferr
+	}
+	if config.KeyType, ferr = o.String("key_type"); ferr != nil {
+		return config, ferr
+	}
+	if config.PublicKey, ferr = o.String("public_key"); ferr != nil {
+		return config, ferr
+	}
+	if config.HashAlgorithms, ferr = o.StringList("hash_algorithms"); ferr != nil {
+		return config, ferr
+	}
+	if config.ServiceTypes, ferr = o.StringList("service_types"); ferr != nil {
+		return config, ferr
+	}
+	if config.Flags, ferr = o.StringList("flags"); ferr != nil {
+		return config, ferr
+	}
+	if config.Notes, ferr = o.String("notes"); ferr != nil {
+		return config, ferr
+	}
+
+	return config, nil
+}
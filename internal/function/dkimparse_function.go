@@ -0,0 +1,103 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+)
+
+var (
+	_ function.Function = DKIMParseFunction{}
+)
+
+var dkimParseAttributeTypes = map[string]attr.Type{
+	"version":         types.StringType,
+	"key_type":        types.StringType,
+	"public_key":      types.StringType,
+	"hash_algorithms": types.ListType{ElemType: types.StringType},
+	"service_types":   types.ListType{ElemType: types.StringType},
+	"flags":           types.ListType{ElemType: types.StringType},
+	"notes":           types.StringType,
+	"bits":            types.Int64Type,
+	"revoked":         types.BoolType,
+	"warnings":        types.ListType{ElemType: types.StringType},
+}
+
+func NewDKIMParseFunction() function.Function {
+	return DKIMParseFunction{}
+}
+
+type DKIMParseFunction struct{}
+
+func (r DKIMParseFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dkim_parse"
+}
+
+func (r DKIMParseFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DKIM Parse function",
+		MarkdownDescription: "Parses a DKIM key record into an object with the attributes accepted by `dkim_builder`, `version`, `key_type`, `public_key` (base64), `hash_algorithms`, `service_types`, `flags` and the decoded `notes`, along with the decoded key size in `bits`, whether the record is `revoked` (empty p=) and a list of `warnings`. `key_type` defaults to `rsa` when the record has no k= tag and absent tags are returned as empty strings and lists. RSA keys under 2048 bits are reported in `warnings`, including keys under 1024 bits that verifiers reject as required by RFC 8301",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "record",
+				MarkdownDescription: "The DKIM key record, such as `v=DKIM1; k=rsa; p=MIIBIjANBg...`",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dkimParseAttributeTypes,
+		},
+	}
+}
+
+func (r DKIMParseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var record string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &record))
+
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := dkimbuilder.DKIMParse(record)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	warnings := []string{}
+	if warning := dkimbuilder.CheckKeyStrength(parsed.Key); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	result := struct {
+		Version        string   `tfsdk:"version"`
+		KeyType        string   `tfsdk:"key_type"`
+		PublicKey      string   `tfsdk:"public_key"`
+		HashAlgorithms []string `tfsdk:"hash_algorithms"`
+		ServiceTypes   []string `tfsdk:"service_types"`
+		Flags          []string `tfsdk:"flags"`
+		Notes          string   `tfsdk:"notes"`
+		Bits           int64    `tfsdk:"bits"`
+		Revoked        bool     `tfsdk:"revoked"`
+		Warnings       []string `tfsdk:"warnings"`
+	}{
+		Version:        parsed.Config.Version,
+		KeyType:        parsed.Config.KeyType,
+		PublicKey:      parsed.Config.PublicKey,
+		HashAlgorithms: nonNil(parsed.Config.HashAlgorithms),
+		ServiceTypes:   nonNil(parsed.Config.ServiceTypes),
+		Flags:          nonNil(parsed.Config.Flags),
+		Notes:          parsed.Config.Notes,
+		Bits:           int64(parsed.Key.Bits),
+		Revoked:        parsed.Revoked,
+		Warnings:       warnings,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
@@ -0,0 +1,206 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+const dkimRSA512KeyData = "MFwwDQYJKoZIhvcNAQEBBQADSwAwSAJBANaCc+cDxntcWMxRsbj3tqZzs57kY2vmHlqSECirPjDHxj/maTfyWKvbH3bnNKr7lwE61WlhFyuu8c2ESLi3WKkCAwEAAQ=="
+
+const dkimRSA1024KeyData = "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC4sjXli1sw41dBdtgIkZNJtkY5gQdea0EtAQ2JzsUzWyF4VGDmMfC8idOiVJ8CHKTjBVkMGXUec0o/yaAY4P35CQQaQueF8LiyzcyoyHVIQ4VW1Xb+cNMLohVCWcfXkjljf2irAHeAhDYRoyUzsRAMgTkDwLg0eB6rjK00TY5D+wIDAQAB"
+
+var dkimParseAttributeTypes = map[string]attr.Type{
+	"version":         types.StringType,
+	"key_type":        types.StringType,
+	"public_key":      types.StringType,
+	"hash_algorithms": types.ListType{ElemType: types.StringType},
+	"service_types":   types.ListType{ElemType: types.StringType},
+	"flags":           types.ListType{ElemType: types.StringType},
+	"notes":           types.StringType,
+	"bits":            types.Int64Type,
+	"revoked":         types.BoolType,
+	"warnings":        types.ListType{ElemType: types.StringType},
+}
+
+func TestDKIMParseFunction_Metadata(t *testing.T) {
+	f := tffunction.NewDKIMParseFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "dkim_parse", resp.Name)
+}
+
+func TestDKIMParseFunction_Definition(t *testing.T) {
+	f := tffunction.NewDKIMParseFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "DKIM Parse function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "record", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.StringType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: dkimParseAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestDKIMParseFunction_Run(t *testing.T) {
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+
+	tests := []struct {
+		name    string
+		record  string
+		want    map[string]attr.Value
+		wantErr string
+	}{
+		{
+			name:   "rsa 2048",
+			record: "v=DKIM1; k=rsa; h=sha256; p=" + dkimRSAKeyData,
+			want: map[string]attr.Value{
+				"version":         types.StringValue("DKIM1"),
+				"key_type":        types.StringValue("rsa"),
+				"public_key":      types.StringValue(dkimRSAKeyData),
+				"hash_algorithms": types.ListValueMust(types.StringType, sliceToValues([]string{"sha256"})),
+				"service_types":   empty,
+				"flags":           empty,
+				"notes":           types.StringValue(""),
+				"bits":            types.Int64Value(2048),
+				"revoked":         types.BoolValue(false),
+				"warnings":        empty,
+			},
+		},
+		{
+			name:   "rsa 1024",
+			record: "p=" + dkimRSA1024KeyData,
+			want: map[string]attr.Value{
+				"version":         types.StringValue(""),
+				"key_type":        types.StringValue("rsa"),
+				"public_key":      types.StringValue(dkimRSA1024KeyData),
+				"hash_algorithms": empty,
+				"service_types":   empty,
+				"flags":           empty,
+				"notes":           types.StringValue(""),
+				"bits":            types.Int64Value(1024),
+				"revoked":         types.BoolValue(false),
+				"warnings":        types.ListValueMust(types.StringType, sliceToValues([]string{"RSA key is 1024 bits, keys of at least 2048 bits should be used (RFC 8301)"})),
+			},
+		},
+		{
+			name:   "revoked",
+			record: "v=DKIM1; k=ed25519; t=y; n=retired; p=",
+			want: map[string]attr.Value{
+				"version":         types.StringValue("DKIM1"),
+				"key_type":        types.StringValue("ed25519"),
+				"public_key":      types.StringValue(""),
+				"hash_algorithms": empty,
+				"service_types":   empty,
+				"flags":           types.ListValueMust(types.StringType, sliceToValues([]string{"y"})),
+				"notes":           types.StringValue("retired"),
+				"bits":            types.Int64Value(0),
+				"revoked":         types.BoolValue(true),
+				"warnings":        empty,
+			},
+		},
+		{
+			name:   "rsa 512",
+			record: "v=DKIM1; p=" + dkimRSA512KeyData,
+			want: map[string]attr.Value{
+				"version":         types.StringValue("DKIM1"),
+				"key_type":        types.StringValue("rsa"),
+				"public_key":      types.StringValue(dkimRSA512KeyData),
+				"hash_algorithms": empty,
+				"service_types":   empty,
+				"flags":           empty,
+				"notes":           types.StringValue(""),
+				"bits":            types.Int64Value(512),
+				"revoked":         types.BoolValue(false),
+				"warnings":        types.ListValueMust(types.StringType, sliceToValues([]string{"RSA key is 512 bits, verifiers reject keys under 1024 bits (RFC 8301)"})),
+			},
+		},
+		{
+			name:    "key type mismatch",
+			record:  "v=DKIM1; k=rsa; p=" + dkimEd25519KeyData,
+			wantErr: "p= is an ed25519 public key but the key type is rsa",
+		},
+		{
+			name:    "not dkim",
+			record:  "v=spf1 -all",
+			wantErr: `invalid DKIM version "spf1 -all"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewDKIMParseFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.record)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(dkimParseAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.ObjectValueMust(dkimParseAttributeTypes, tt.want)), resp.Result)
+		})
+	}
+}
+
+func TestAccDKIMParseFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+locals {
+  dkim = provider::dnshelper::dkim_parse("v=DKIM1; p=` + dkimRSA1024KeyData + `")
+}
+
+output "key_type" {
+  value = local.dkim.key_type
+}
+
+output "bits" {
+  value = local.dkim.bits
+}
+
+output "warnings" {
+  value = length(local.dkim.warnings)
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("key_type", "rsa"),
+						resource.TestCheckOutput("bits", "1024"),
+						resource.TestCheckOutput("warnings", "1"),
+					),
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,178 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+var (
+	_ function.Function = DKIMRotationFunction{}
+)
+
+var dkimRotationFields = []fieldParameter{
+	{"SelectorFormat", "selector_format"},
+	{"CurrentDate", "current_date"},
+	{"PreviousDate", "previous_date"},
+	{"CurrentKey", "current_key"},
+	{"PreviousKey", "previous_key"},
+	{"Phase", "phase"},
+	{"Domain", "domain"},
+	{"Version", "version"},
+	{"HashAlgorithms", "hash_algorithms"},
+	{"ServiceTypes", "service_types"},
+	{"Flags", "flags"},
+	{"Notes", "notes"},
+	// RecordName reports record names that are too long against the
+	// selector.
+	{"Selector", "selector_format"},
+}
+
+var dkimRotationRecordAttributeTypes = map[string]attr.Type{
+	"selector": types.StringType,
+	"name":     types.StringType,
+	"record":   types.StringType,
+	"revoked":  types.BoolType,
+	"chunks":   types.ListType{ElemType: types.StringType},
+}
+
+var dkimRotationAttributeTypes = map[string]attr.Type{
+	"signing_selector": types.StringType,
+	"records":          types.ListType{ElemType: types.ObjectType{AttrTypes: dkimRotationRecordAttributeTypes}},
+}
+
+func NewDKIMRotationFunction() function.Function {
+	return DKIMRotationFunction{}
+}
+
+type DKIMRotationFunction struct{}
+
+func (r DKIMRotationFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dkim_rotation"
+}
+
+func (r DKIMRotationFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DKIM key rotation function",
+		MarkdownDescription: "Returns the DKIM records to publish while rotating from a previous key to a current one. The object argument has the attributes `selector_format`, `current_date`, `previous_date`, `current_key`, `previous_key`, `phase` and `domain`, along with the `dkim_builder` attributes `version`, `hash_algorithms`, `service_types`, `flags` and `notes`, which are shared by the records of both keys. Selectors are named from the key dates with `selector_format`, which supports `%Y`, `%y`, `%m`, `%d` and `%%`, e.g. `s%Y%m`. Dates are RFC 3339 timestamps or YYYY-MM-DD dates. `phase` is one of `prepublish` (both keys published, signing with the previous one), `active` (both keys published, signing with the current one), `revoke` (the previous selector is revoked with an empty p= key, so `previous_key` is not needed) and `complete` (only the current key is published, so neither `previous_date` nor `previous_key` is needed). Returns an object with the `signing_selector` and the `records`, current key first, each with its `selector`, record `name`, `record` value, whether it is `revoked` and the value split into 255 byte `chunks`",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the DKIM rotation attributes",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dkimRotationAttributeTypes,
+		},
+	}
+}
+
+func (r DKIMRotationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, ferr := dkimRotationConfigFromObject(0, value)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	records, signing, err := dkimbuilder.Rotation(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, dkimRotationFields))
+		return
+	}
+
+	type recordModel struct {
+		Selector string   `tfsdk:"selector"`
+		Name     string   `tfsdk:"name"`
+		Record   string   `tfsdk:"record"`
+		Revoked  bool     `tfsdk:"revoked"`
+		Chunks   []string `tfsdk:"chunks"`
+	}
+
+	result := struct {
+		SigningSelector string        `tfsdk:"signing_selector"`
+		Records         []recordModel `tfsdk:"records"`
+	}{
+		SigningSelector: signing,
+		Records:         make([]recordModel, 0, len(records)),
+	}
+	for _, record := range records {
+		result.Records = append(result.Records, recordModel{
+			Selector: record.Selector,
+			Name:     record.Name,
+			Record:   record.Value,
+			Revoked:  record.Revoked,
+			Chunks:   dkimbuilder.SplitTXT(record.Value, dkimbuilder.TXTStringSize),
+		})
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+func dkimRotationConfigFromObject(index int64, value types.Dynamic) (dkimbuilder.RotationConfig, *function.FuncError) {
+	var config dkimbuilder.RotationConfig
+
+	o, ferr := newObjectArgument(index, value, fieldParameterNames(dkimRotationFields)...)
+	if ferr != nil {
+		return config, ferr
+	}
+
+	if config.SelectorFormat, ferr = o.String("selector_format"); ferr != nil {
+		return config, ferr
+	}
+	if config.CurrentKey, ferr = o.String("current_key"); ferr != nil {
+		return config, ferr
+	}
+	if config.PreviousKey, ferr = o.String("previous_key"); ferr != nil {
+		return config, ferr
+	}
+	if config.Phase, ferr = o.String("phase"); ferr != nil {
+		return config, ferr
+	}
+	if config.Domain, ferr = o.String("domain"); ferr != nil {
+		return config, ferr
+	}
+
+	for _, date := range []struct {
+		field     string
+		attribute string
+		target    *time.Time
+	}{
+		{"CurrentDate", "current_date", &config.CurrentDate},
+		{"PreviousDate", "previous_date", &config.PreviousDate},
+	} {
+		s, ferr := o.String(date.attribute)
+		if ferr != nil {
+			return config, ferr
+		}
+		if s == "" {
+			continue
+		}
+		t, err := parseDate(s)
+		if err != nil {
+			return config, fieldAttributeError(index, fielderror.Wrap(date.field, err), dkimRotationFields)
+		}
+		*date.target = t
+	}
+
+	if config.Template, ferr = dkimConfigFromObject(o); ferr != nil {
+		return config, ferr
+	}
+
+	return config, nil
+}
@@ -0,0 +1,224 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var dkimRotationRecordAttributeTypes = map[string]attr.Type{
+	"selector": types.StringType,
+	"name":     types.StringType,
+	"record":   types.StringType,
+	"revoked":  types.BoolType,
+	"chunks":   types.ListType{ElemType: types.StringType},
+}
+
+var dkimRotationAttributeTypes = map[string]attr.Type{
+	"signing_selector": types.StringType,
+	"records":          types.ListType{ElemType: types.ObjectType{AttrTypes: dkimRotationRecordAttributeTypes}},
+}
+
+func dkimRotationRecordValue(selector, name, record string, revoked bool) attr.Value {
+	return types.ObjectValueMust(dkimRotationRecordAttributeTypes, map[string]attr.Value{
+		"selector": types.StringValue(selector),
+		"name":     types.StringValue(name),
+		"record":   types.StringValue(record),
+		"revoked":  types.BoolValue(revoked),
+		"chunks":   types.ListValueMust(types.StringType, sliceToValues([]string{record})),
+	})
+}
+
+func TestDKIMRotationFunction_Metadata(t *testing.T) {
+	f := tffunction.NewDKIMRotationFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "dkim_rotation", resp.Name)
+}
+
+func TestDKIMRotationFunction_Definition(t *testing.T) {
+	f := tffunction.NewDKIMRotationFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "DKIM key rotation function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: dkimRotationAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestDKIMRotationFunction_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		attrs       map[string]attr.Value
+		wantSigning string
+		want        []attr.Value
+		wantErr     string
+	}{
+		{
+			name: "active",
+			attrs: map[string]attr.Value{
+				"selector_format": types.StringValue("s%Y%m"),
+				"current_date":    types.StringValue("2026-07-01"),
+				"previous_date":   types.StringValue("2026-01-01T00:00:00Z"),
+				"current_key":     types.StringValue(dkimEd25519PublicKey),
+				"previous_key":    types.StringValue(dkimEd25519KeyData),
+				"phase":           types.StringValue("active"),
+				"domain":          types.StringValue("example.com"),
+				"flags":           types.ListValueMust(types.StringType, sliceToValues([]string{"s"})),
+			},
+			wantSigning: "s202607",
+			want: []attr.Value{
+				dkimRotationRecordValue("s202607", "s202607._domainkey.example.com", "v=DKIM1; k=ed25519; t=s; p="+dkimEd25519KeyData, false),
+				dkimRotationRecordValue("s202601", "s202601._domainkey.example.com", "v=DKIM1; k=ed25519; t=s; p="+dkimEd25519KeyData, false),
+			},
+		},
+		{
+			name: "revoke",
+			attrs: map[string]attr.Value{
+				"selector_format": types.StringValue("ed%y%m"),
+				"current_date":    types.StringValue("2026-07-01"),
+				"previous_date":   types.StringValue("2026-01-01"),
+				"current_key":     types.StringValue(dkimEd25519PublicKey),
+				"phase":           types.StringValue("revoke"),
+			},
+			wantSigning: "ed2607",
+			want: []attr.Value{
+				dkimRotationRecordValue("ed2607", "ed2607._domainkey", "v=DKIM1; k=ed25519; p="+dkimEd25519KeyData, false),
+				dkimRotationRecordValue("ed2601", "ed2601._domainkey", "v=DKIM1; p=", true),
+			},
+		},
+		{
+			name: "invalid date",
+			attrs: map[string]attr.Value{
+				"selector_format": types.StringValue("s%Y%m"),
+				"current_date":    types.StringValue("July 2026"),
+				"current_key":     types.StringValue(dkimEd25519PublicKey),
+				"phase":           types.StringValue("complete"),
+			},
+			wantErr: `attribute "current_date": parsing time "July 2026"`,
+		},
+		{
+			name: "missing previous key",
+			attrs: map[string]attr.Value{
+				"selector_format": types.StringValue("s%Y%m"),
+				"current_date":    types.StringValue("2026-07-01"),
+				"previous_date":   types.StringValue("2026-01-01"),
+				"current_key":     types.StringValue(dkimEd25519PublicKey),
+				"phase":           types.StringValue("prepublish"),
+			},
+			wantErr: `attribute "previous_key": previous key must not be empty in the prepublish phase`,
+		},
+		{
+			name: "invalid selector format",
+			attrs: map[string]attr.Value{
+				"selector_format": types.StringValue("s%H"),
+				"current_date":    types.StringValue("2026-07-01"),
+				"current_key":     types.StringValue(dkimEd25519PublicKey),
+				"phase":           types.StringValue("complete"),
+			},
+			wantErr: `attribute "selector_format": unsupported selector format verb %H`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewDKIMRotationFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(dkimRotationAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(dkimRotationAttributeTypes, map[string]attr.Value{
+				"signing_selector": types.StringValue(tt.wantSigning),
+				"records":          types.ListValueMust(types.ObjectType{AttrTypes: dkimRotationRecordAttributeTypes}, tt.want),
+			})
+			require.Equal(t, function.NewResultData(want), resp.Result)
+		})
+	}
+}
+
+func TestDKIMRotationFunction_UnsupportedAttribute(t *testing.T) {
+	f := tffunction.NewDKIMRotationFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(map[string]attr.Value{
+			"selector":     types.StringValue("s%Y%m"),
+			"current_date": types.StringValue("2026-07-01"),
+		})}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(dkimRotationAttributeTypes)),
+	}
+	f.Run(context.Background(), req, resp)
+
+	require.NotNil(t, resp.Error)
+	require.Equal(t, `attribute "selector": unsupported attribute, expected one of: selector_format, current_date, previous_date, current_key, previous_key, phase, domain, version, hash_algorithms, service_types, flags, notes`, resp.Error.Text)
+}
+
+func TestAccDKIMRotationFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+locals {
+  rotation = provider::dnshelper::dkim_rotation({
+    selector_format = "s%Y%m"
+    current_date    = "2026-07-01"
+    previous_date   = "2026-01-01"
+    current_key     = "` + dkimEd25519KeyData + `"
+    phase           = "revoke"
+  })
+}
+
+output "signing_selector" {
+  value = local.rotation.signing_selector
+}
+
+output "records" {
+  value = jsonencode({ for r in local.rotation.records : r.name => r.record })
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("signing_selector", "s202607"),
+						resource.TestCheckOutput("records", `{"s202601._domainkey":"v=DKIM1; p=","s202607._domainkey":"v=DKIM1; k=ed25519; p=`+dkimEd25519KeyData+`"}`),
+					),
+				},
+			},
+		},
+	)
+}
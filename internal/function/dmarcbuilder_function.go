@@ -5,7 +5,6 @@ package function
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -16,6 +15,22 @@ var (
 	_ function.Function = DmarcBuilderFunction{}
 )
 
+// dmarcBuilderFields maps DMARCConfig fields to dmarc_builder parameters, in
+// parameter order.
+var dmarcBuilderFields = []fieldParameter{
+	{"Version", "version"},
+	{"Policy", "policy"},
+	{"SubdomainPolicy", "subdomain_policy"},
+	{"AlignmentSPF", "alignment_spf"},
+	{"AlignmentDKIM", "alignment_dkim"},
+	{"Percent", "percent"},
+	{"RUA", "rua"},
+	{"RUF", "ruf"},
+	{"FailureOptions", "failure_options"},
+	{"FailureFormat", "failure_format"},
+	{"ReportInterval", "report_interval"},
+}
+
 func NewDmarcBuilderFunction() function.Function {
 	return DmarcBuilderFunction{}
 }
@@ -58,12 +73,12 @@ func (r DmarcBuilderFunction) Definition(_ context.Context, _ function.Definitio
 			function.ListParameter{
 				ElementType:         types.StringType,
 				Name:                "rua",
-				MarkdownDescription: "Array of aggregate report targets",
+				MarkdownDescription: "Array of aggregate report targets, absolute URIs such as `mailto:dmarc@example.com` optionally followed by a size limit such as `!10m`",
 			},
 			function.ListParameter{
 				ElementType:         types.StringType,
 				Name:                "ruf",
-				MarkdownDescription: "Array of failure report targets",
+				MarkdownDescription: "Array of failure report targets, absolute URIs such as `mailto:dmarc@example.com` optionally followed by a size limit such as `!10m`",
 			},
 			function.StringParameter{
 				Name:                "failure_options",
@@ -100,7 +115,6 @@ func (r DmarcBuilderFunction) Run(ctx context.Context, req function.RunRequest,
 	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data.Version, &data.Policy, &data.SubdomainPolicy, &data.AlignmentSPF, &data.AlignmentDKIM, &data.Percent, &data.RUA, &data.RUF, &data.FailureOptions, &data.FailureFormat, &data.ReportInterval))
 
 	if resp.Error != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Errorf("failed to get arguments").Error()))
 		return
 	}
 
@@ -119,7 +133,7 @@ func (r DmarcBuilderFunction) Run(ctx context.Context, req function.RunRequest,
 	}
 	result, err := dmarcbuilder.DmarcBuilder(config)
 	if err != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, dmarcBuilderFields))
 		return
 	}
 
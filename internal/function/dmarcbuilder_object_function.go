@@ -0,0 +1,122 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+)
+
+var (
+	_ function.Function = DmarcBuilderObjectFunction{}
+)
+
+// dmarcBuilderObjectFields extends the dmarc_builder parameters with the
+// attributes only available in the object form.
+var dmarcBuilderObjectFields = append(dmarcBuilderFields[:len(dmarcBuilderFields):len(dmarcBuilderFields)],
+	fieldParameter{"NonexistentPolicy", "nonexistent_policy"},
+	fieldParameter{"Testing", "testing"},
+)
+
+func NewDmarcBuilderObjectFunction() function.Function {
+	return DmarcBuilderObjectFunction{}
+}
+
+type DmarcBuilderObjectFunction struct{}
+
+func (r DmarcBuilderObjectFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dmarc_builder_object"
+}
+
+func (r DmarcBuilderObjectFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DMARC Builder function with object argument",
+		MarkdownDescription: "Builds a DMARC record from an object with optional attributes: `version`, `policy`, `subdomain_policy`, `nonexistent_policy`, `alignment_spf`, `alignment_dkim`, `percent`, `testing`, `rua`, `ruf`, `failure_options`, `failure_format` and `report_interval`. Attributes have the same meaning as the `dmarc_builder` parameters of the same name, `nonexistent_policy` sets the policy for non-existent subdomains (np=) and `testing` publishes the record in testing mode (t=y)",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the DMARC record attributes, all of which are optional",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (r DmarcBuilderObjectFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, ferr := dmarcConfigFromObject(0, value)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	result, err := dmarcbuilder.DmarcBuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, dmarcBuilderObjectFields))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+func dmarcConfigFromObject(index int64, value types.Dynamic) (dmarcbuilder.DMARCConfig, *function.FuncError) {
+	var config dmarcbuilder.DMARCConfig
+
+	o, ferr := newObjectArgument(index, value, fieldParameterNames(dmarcBuilderObjectFields)...)
+	if ferr != nil {
+		return config, ferr
+	}
+
+	if config.Version, ferr = o.String("version"); ferr != nil {
+		return config, ferr
+	}
+	if config.Policy, ferr = o.String("policy"); ferr != nil {
+		return config, ferr
+	}
+	if config.SubdomainPolicy, ferr = o.String("subdomain_policy"); ferr != nil {
+		return config, ferr
+	}
+	if config.NonexistentPolicy, ferr = o.String("nonexistent_policy"); ferr != nil {
+		return config, ferr
+	}
+	if config.AlignmentSPF, ferr = o.String("alignment_spf"); ferr != nil {
+		return config, ferr
+	}
+	if config.AlignmentDKIM, ferr = o.String("alignment_dkim"); ferr != nil {
+		return config, ferr
+	}
+	if config.Percent, ferr = o.Int32("percent"); ferr != nil {
+		return config, ferr
+	}
+	if config.Testing, ferr = o.Bool("testing"); ferr != nil {
+		return config, ferr
+	}
+	if config.RUA, ferr = o.StringList("rua"); ferr != nil {
+		return config, ferr
+	}
+	if config.RUF, ferr = o.StringList("ruf"); ferr != nil {
+		return config, ferr
+	}
+	if config.FailureOptions, ferr = o.String("failure_options"); ferr != nil {
+		return config, ferr
+	}
+	if config.FailureFormat, ferr = o.String("failure_format"); ferr != nil {
+		return config, ferr
+	}
+	if config.ReportInterval, ferr = o.Int32("report_interval"); ferr != nil {
+		return config, ferr
+	}
+
+	return config, nil
+}
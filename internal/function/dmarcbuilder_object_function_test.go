@@ -0,0 +1,206 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+func TestDmarcBuilderObjectFunction_Metadata(t *testing.T) {
+	f := tffunction.NewDmarcBuilderObjectFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "dmarc_builder_object", resp.Name)
+}
+
+func TestDmarcBuilderObjectFunction_Definition(t *testing.T) {
+	f := tffunction.NewDmarcBuilderObjectFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "DMARC Builder function with object argument", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+}
+
+func TestDmarcBuilderObjectFunction_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]attr.Value
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "empty object",
+			attrs: map[string]attr.Value{},
+			want:  "v=DMARC1; p=none",
+		},
+		{
+			name: "named attributes",
+			attrs: map[string]attr.Value{
+				"policy":         types.StringValue("reject"),
+				"alignment_spf":  types.StringValue("strict"),
+				"alignment_dkim": types.StringValue("relaxed"),
+				"percent":        types.NumberValue(big.NewFloat(50)),
+				"rua":            types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("mailto:dmarc@example.com")}),
+			},
+			want: "v=DMARC1; p=reject; adkim=r; aspf=s; pct=50; rua=mailto:dmarc@example.com",
+		},
+		{
+			name: "nonexistent policy",
+			attrs: map[string]attr.Value{
+				"policy":             types.StringValue("quarantine"),
+				"subdomain_policy":   types.StringValue("quarantine"),
+				"nonexistent_policy": types.StringValue("reject"),
+			},
+			want: "v=DMARC1; p=quarantine; sp=quarantine; np=reject",
+		},
+		{
+			name: "testing mode",
+			attrs: map[string]attr.Value{
+				"policy":  types.StringValue("reject"),
+				"testing": types.BoolValue(true),
+			},
+			want: "v=DMARC1; p=reject; t=y",
+		},
+		{
+			name: "null attributes are ignored",
+			attrs: map[string]attr.Value{
+				"policy":          types.StringValue("quarantine"),
+				"report_interval": types.DynamicNull(),
+			},
+			want: "v=DMARC1; p=quarantine",
+		},
+		{
+			name: "unsupported attribute",
+			attrs: map[string]attr.Value{
+				"polcy": types.StringValue("reject"),
+			},
+			wantErr: `attribute "polcy": unsupported attribute`,
+		},
+		{
+			name: "wrong attribute type",
+			attrs: map[string]attr.Value{
+				"percent": types.StringValue("fifty"),
+			},
+			wantErr: `attribute "percent": must be a number`,
+		},
+		{
+			name: "fractional number",
+			attrs: map[string]attr.Value{
+				"percent": types.NumberValue(big.NewFloat(10.5)),
+			},
+			wantErr: `attribute "percent": must be a whole number`,
+		},
+		{
+			name: "wrong list element type",
+			attrs: map[string]attr.Value{
+				"rua": types.TupleValueMust([]attr.Type{types.BoolType}, []attr.Value{types.BoolValue(true)}),
+			},
+			wantErr: `attribute "rua[0]": must be a string`,
+		},
+		{
+			name: "invalid policy",
+			attrs: map[string]attr.Value{
+				"policy": types.StringValue("invalid"),
+			},
+			wantErr: "invalid DMARC policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewDmarcBuilderObjectFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.StringValue(tt.want)), resp.Result)
+		})
+	}
+}
+
+func TestDmarcBuilderObjectFunction_Run_NotObject(t *testing.T) {
+	f := tffunction.NewDmarcBuilderObjectFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(types.StringValue("reject"))}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+	f.Run(context.Background(), req, resp)
+
+	require.NotNil(t, resp.Error)
+	require.Equal(t, "argument must be an object", resp.Error.Text)
+}
+
+func TestAccDmarcBuilderObjectFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output" {
+  value = provider::dnshelper::dmarc_builder_object({
+    policy           = "reject"
+    subdomain_policy = "quarantine"
+    alignment_dkim   = "strict"
+    rua              = ["mailto:admin@malmeida.dev"]
+  })
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output",
+							"v=DMARC1; p=reject; sp=quarantine; adkim=s; rua=mailto:admin@malmeida.dev",
+						),
+					),
+				},
+			},
+		},
+	)
+}
+
+func objectToDynamic(attrs map[string]attr.Value) types.Dynamic {
+	attrTypes := make(map[string]attr.Type, len(attrs))
+	for k, v := range attrs {
+		attrTypes[k] = v.Type(context.Background())
+	}
+	return types.DynamicValue(types.ObjectValueMust(attrTypes, attrs))
+}
@@ -0,0 +1,98 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+)
+
+var (
+	_ function.Function = DmarcEffectivePolicyFunction{}
+)
+
+var dmarcEffectivePolicyFields = []fieldParameter{
+	{"Hostname", "hostname"},
+	{"NonExistent", "non_existent"},
+}
+
+var dmarcEffectivePolicyAttributeTypes = map[string]attr.Type{
+	"hostname":              types.StringType,
+	"organizational_domain": types.StringType,
+	"record_name":           types.StringType,
+	"record":                types.StringType,
+	"policy_tag":            types.StringType,
+	"policy":                types.StringType,
+}
+
+func NewDmarcEffectivePolicyFunction() function.Function {
+	return DmarcEffectivePolicyFunction{}
+}
+
+type DmarcEffectivePolicyFunction struct{}
+
+func (r DmarcEffectivePolicyFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dmarc_effective_policy"
+}
+
+func (r DmarcEffectivePolicyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DMARC effective policy function",
+		MarkdownDescription: "Looks up the DMARC policy that applies to a hostname, falling back to the `sp=` (or `np=`) policy of its organizational domain when the hostname publishes no `_dmarc` record. The organizational domain is determined using an embedded Public Suffix List snapshot. Returns an object with `hostname`, `organizational_domain`, `record_name`, `record`, `policy_tag` (`p`, `sp` or `np`) and `policy`; `record_name`, `record`, `policy_tag` and `policy` are empty when no DMARC record applies",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "hostname",
+				MarkdownDescription: "The hostname to evaluate the DMARC policy for",
+			},
+			function.BoolParameter{
+				Name:                "non_existent",
+				MarkdownDescription: "Whether the hostname does not exist in DNS, in which case the organizational domain `np=` policy applies when published",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dmarcEffectivePolicyAttributeTypes,
+		},
+	}
+}
+
+func (r DmarcEffectivePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data struct {
+		Hostname    string `tfsdk:"hostname"`
+		NonExistent bool   `tfsdk:"non_existent"`
+	}
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data.Hostname, &data.NonExistent))
+
+	if resp.Error != nil {
+		return
+	}
+
+	policy, err := dmarcbuilder.LookupEffectivePolicy(data.Hostname, data.NonExistent, newResolver())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, dmarcEffectivePolicyFields))
+		return
+	}
+
+	result := struct {
+		Hostname             string `tfsdk:"hostname"`
+		OrganizationalDomain string `tfsdk:"organizational_domain"`
+		RecordName           string `tfsdk:"record_name"`
+		Record               string `tfsdk:"record"`
+		PolicyTag            string `tfsdk:"policy_tag"`
+		Policy               string `tfsdk:"policy"`
+	}{
+		Hostname:             policy.Hostname,
+		OrganizationalDomain: policy.OrganizationalDomain,
+		RecordName:           policy.RecordName,
+		Record:               policy.Record,
+		PolicyTag:            policy.PolicyTag,
+		Policy:               policy.Policy,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
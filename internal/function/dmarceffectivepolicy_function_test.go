@@ -0,0 +1,126 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var dmarcEffectivePolicyAttributeTypes = map[string]attr.Type{
+	"hostname":              types.StringType,
+	"organizational_domain": types.StringType,
+	"record_name":           types.StringType,
+	"record":                types.StringType,
+	"policy_tag":            types.StringType,
+	"policy":                types.StringType,
+}
+
+func TestDmarcEffectivePolicyFunction_Metadata(t *testing.T) {
+	f := tffunction.NewDmarcEffectivePolicyFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "dmarc_effective_policy", resp.Name)
+}
+
+func TestDmarcEffectivePolicyFunction_Definition(t *testing.T) {
+	f := tffunction.NewDmarcEffectivePolicyFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "DMARC effective policy function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 2)
+	require.Equal(t, "hostname", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, "non_existent", resp.Definition.Parameters[1].GetName())
+	require.Equal(t, types.ObjectType{AttrTypes: dmarcEffectivePolicyAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestDmarcEffectivePolicyFunction_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostname     string
+		nonExistent  bool
+		want         map[string]string
+		wantArgument *int64
+	}{
+		{
+			name:     "subdomain inherits sp",
+			hostname: "www.example.com",
+			want: map[string]string{
+				"hostname":              "www.example.com",
+				"organizational_domain": "example.com",
+				"record_name":           "_dmarc.example.com",
+				"record":                "v=DMARC1; p=reject; sp=quarantine; rua=mailto:dmarc@example.com",
+				"policy_tag":            "sp",
+				"policy":                "quarantine",
+			},
+		},
+		{
+			name:        "non existent subdomain",
+			hostname:    "nx.example.org",
+			nonExistent: true,
+			want: map[string]string{
+				"hostname":              "nx.example.org",
+				"organizational_domain": "example.org",
+				"record_name":           "_dmarc.example.org",
+				"record":                "v=DMARC1; p=quarantine; np=reject",
+				"policy_tag":            "np",
+				"policy":                "reject",
+			},
+		},
+		{
+			name:     "no record",
+			hostname: "example.edu",
+			want: map[string]string{
+				"hostname":              "example.edu",
+				"organizational_domain": "example.edu",
+				"record_name":           "",
+				"record":                "",
+				"policy_tag":            "",
+				"policy":                "",
+			},
+		},
+		{
+			name:         "public suffix",
+			hostname:     "co.uk",
+			wantArgument: func() *int64 { i := int64(0); return &i }(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewDmarcEffectivePolicyFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.hostname),
+					types.BoolValue(tt.nonExistent),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(dmarcEffectivePolicyAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantArgument != nil {
+				require.NotNil(t, resp.Error)
+				require.Equal(t, tt.wantArgument, resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			attrs := map[string]attr.Value{}
+			for k, v := range tt.want {
+				attrs[k] = types.StringValue(v)
+			}
+			require.Equal(t, function.NewResultData(types.ObjectValueMust(dmarcEffectivePolicyAttributeTypes, attrs)), resp.Result)
+		})
+	}
+}
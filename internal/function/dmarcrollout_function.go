@@ -0,0 +1,252 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+var (
+	_ function.Function = DmarcRolloutFunction{}
+	_ function.Function = DmarcRolloutScheduleFunction{}
+)
+
+var dmarcRolloutFields = []fieldParameter{
+	{"Target", "target"},
+	{"Ramp", "ramp"},
+	{"Stage", "stage"},
+	{"Config", "config"},
+}
+
+var dmarcRolloutScheduleFields = []fieldParameter{
+	{"Target", "target"},
+	{"Ramp", "ramp"},
+	{"Schedule", "schedule"},
+	{"Now", "now"},
+	{"Config", "config"},
+}
+
+var dmarcRolloutAttributeTypes = map[string]attr.Type{
+	"stage":       types.Int64Type,
+	"stage_count": types.Int64Type,
+	"policy":      types.StringType,
+	"percent":     types.Int64Type,
+	"testing":     types.BoolType,
+	"record":      types.StringType,
+}
+
+const dmarcRolloutDescription = "The rollout moves from `p=none` to the target policy. With the `pct` ramp the stages towards `reject` are none, quarantine with pct 10, 50 and 100, and reject; with the `t` ramp they are none, quarantine with `t=y`, quarantine, reject with `t=y` and reject. Returns an object with the `stage` index, the `stage_count`, the stage `policy`, `percent` and `testing` values, and the DMARC `record`"
+
+var dmarcRolloutTargetParameter = function.StringParameter{
+	Name:                "target",
+	MarkdownDescription: "The policy the rollout ends at, must be one of 'none', 'quarantine', 'reject'",
+}
+
+var dmarcRolloutRampParameter = function.StringParameter{
+	Name:                "ramp",
+	MarkdownDescription: "How the rollout ramps up each policy, 'pct' for pct= percentages or 't' for the DMARCbis testing flag (default: 'pct')",
+}
+
+var dmarcRolloutConfigParameter = function.DynamicParameter{
+	Name:                "config",
+	MarkdownDescription: "Object with the remaining DMARC record attributes, as accepted by `dmarc_builder_object`. `policy`, `percent` and `testing` are set by the rollout and must not be given",
+}
+
+func NewDmarcRolloutFunction() function.Function {
+	return DmarcRolloutFunction{}
+}
+
+type DmarcRolloutFunction struct{}
+
+func (r DmarcRolloutFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dmarc_rollout"
+}
+
+func (r DmarcRolloutFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DMARC rollout stage function",
+		MarkdownDescription: "Builds the DMARC record for a stage of a staged policy rollout. " + dmarcRolloutDescription,
+		Parameters: []function.Parameter{
+			dmarcRolloutTargetParameter,
+			dmarcRolloutRampParameter,
+			function.Int64Parameter{
+				Name:                "stage",
+				MarkdownDescription: "The rollout stage, starting at 0. Stages past the last one stay at the target policy",
+			},
+			dmarcRolloutConfigParameter,
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dmarcRolloutAttributeTypes,
+		},
+	}
+}
+
+func (r DmarcRolloutFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data struct {
+		Target string        `tfsdk:"target"`
+		Ramp   string        `tfsdk:"ramp"`
+		Stage  int64         `tfsdk:"stage"`
+		Config types.Dynamic `tfsdk:"config"`
+	}
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data.Target, &data.Ramp, &data.Stage, &data.Config))
+
+	if resp.Error != nil {
+		return
+	}
+
+	base, ferr := dmarcConfigFromObject(3, data.Config)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	if data.Stage < 0 {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(2, "rollout stage must not be negative"))
+		return
+	}
+
+	config, err := dmarcbuilder.Rollout(base, data.Target, data.Ramp, int(min(data.Stage, 1<<16)))
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, dmarcRolloutError(err, dmarcRolloutFields))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, setDmarcRolloutResult(ctx, resp, config, int(data.Stage), data.Target, data.Ramp, dmarcRolloutFields))
+}
+
+func NewDmarcRolloutScheduleFunction() function.Function {
+	return DmarcRolloutScheduleFunction{}
+}
+
+type DmarcRolloutScheduleFunction struct{}
+
+func (r DmarcRolloutScheduleFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dmarc_rollout_schedule"
+}
+
+func (r DmarcRolloutScheduleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DMARC rollout schedule function",
+		MarkdownDescription: "Builds the DMARC record for the stage of a staged policy rollout in effect at a given date. " + dmarcRolloutDescription,
+		Parameters: []function.Parameter{
+			dmarcRolloutTargetParameter,
+			dmarcRolloutRampParameter,
+			function.ListParameter{
+				ElementType:         types.StringType,
+				Name:                "schedule",
+				MarkdownDescription: "Start dates of every stage after the first, in ascending order, as RFC 3339 timestamps or YYYY-MM-DD dates",
+			},
+			function.StringParameter{
+				Name:                "now",
+				MarkdownDescription: "The current date, as an RFC 3339 timestamp or YYYY-MM-DD date, e.g. `plantimestamp()`",
+			},
+			dmarcRolloutConfigParameter,
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dmarcRolloutAttributeTypes,
+		},
+	}
+}
+
+func (r DmarcRolloutScheduleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data struct {
+		Target   string        `tfsdk:"target"`
+		Ramp     string        `tfsdk:"ramp"`
+		Schedule []string      `tfsdk:"schedule"`
+		Now      string        `tfsdk:"now"`
+		Config   types.Dynamic `tfsdk:"config"`
+	}
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data.Target, &data.Ramp, &data.Schedule, &data.Now, &data.Config))
+
+	if resp.Error != nil {
+		return
+	}
+
+	base, ferr := dmarcConfigFromObject(4, data.Config)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	schedule := make([]time.Time, 0, len(data.Schedule))
+	for i, s := range data.Schedule {
+		date, err := parseDate(s)
+		if err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(fielderror.Wrap(fielderror.Index("Schedule", i), err), dmarcRolloutScheduleFields))
+			return
+		}
+		schedule = append(schedule, date)
+	}
+
+	now, err := parseDate(data.Now)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(fielderror.Wrap("Now", err), dmarcRolloutScheduleFields))
+		return
+	}
+
+	config, stage, err := dmarcbuilder.ScheduledRollout(base, data.Target, data.Ramp, schedule, now)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, dmarcRolloutError(err, dmarcRolloutScheduleFields))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, setDmarcRolloutResult(ctx, resp, config, stage, data.Target, data.Ramp, dmarcRolloutScheduleFields))
+}
+
+// dmarcRolloutError reports errors for the rollout parameters against their
+// argument, and errors for DMARCConfig fields against the config attribute.
+func dmarcRolloutError(err error, fields []fieldParameter) *function.FuncError {
+	if i, _, fe := lookupFieldParameter(err, fields); i >= 0 || fe == nil {
+		return fieldArgumentError(err, fields)
+	}
+	return fieldAttributeError(int64(len(fields)-1), err, dmarcBuilderObjectFields)
+}
+
+func setDmarcRolloutResult(ctx context.Context, resp *function.RunResponse, config dmarcbuilder.DMARCConfig, stage int, target string, ramp string, fields []fieldParameter) *function.FuncError {
+	stages, err := dmarcbuilder.RolloutStages(target, ramp)
+	if err != nil {
+		return dmarcRolloutError(err, fields)
+	}
+
+	record, err := dmarcbuilder.DmarcBuilder(config)
+	if err != nil {
+		return dmarcRolloutError(err, fields)
+	}
+
+	result := struct {
+		Stage      int64  `tfsdk:"stage"`
+		StageCount int64  `tfsdk:"stage_count"`
+		Policy     string `tfsdk:"policy"`
+		Percent    int64  `tfsdk:"percent"`
+		Testing    bool   `tfsdk:"testing"`
+		Record     string `tfsdk:"record"`
+	}{
+		Stage:      int64(min(stage, len(stages)-1)),
+		StageCount: int64(len(stages)),
+		Policy:     config.Policy,
+		Percent:    int64(config.Percent),
+		Testing:    config.Testing,
+		Record:     record,
+	}
+
+	return resp.Result.Set(ctx, &result)
+}
+
+// parseDate accepts RFC 3339 timestamps, as returned by timestamp() and
+// plantimestamp(), and plain YYYY-MM-DD dates.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
@@ -0,0 +1,289 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var dmarcRolloutAttributeTypes = map[string]attr.Type{
+	"stage":       types.Int64Type,
+	"stage_count": types.Int64Type,
+	"policy":      types.StringType,
+	"percent":     types.Int64Type,
+	"testing":     types.BoolType,
+	"record":      types.StringType,
+}
+
+type dmarcRolloutResult struct {
+	stage      int64
+	stageCount int64
+	policy     string
+	percent    int64
+	testing    bool
+	record     string
+}
+
+func (r dmarcRolloutResult) value() function.ResultData {
+	return function.NewResultData(types.ObjectValueMust(dmarcRolloutAttributeTypes, map[string]attr.Value{
+		"stage":       types.Int64Value(r.stage),
+		"stage_count": types.Int64Value(r.stageCount),
+		"policy":      types.StringValue(r.policy),
+		"percent":     types.Int64Value(r.percent),
+		"testing":     types.BoolValue(r.testing),
+		"record":      types.StringValue(r.record),
+	}))
+}
+
+func TestDmarcRolloutFunction_Metadata(t *testing.T) {
+	f := tffunction.NewDmarcRolloutFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "dmarc_rollout", resp.Name)
+}
+
+func TestDmarcRolloutFunction_Definition(t *testing.T) {
+	f := tffunction.NewDmarcRolloutFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "DMARC rollout stage function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 4)
+	require.Equal(t, "target", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, "ramp", resp.Definition.Parameters[1].GetName())
+	require.Equal(t, "stage", resp.Definition.Parameters[2].GetName())
+	require.Equal(t, "config", resp.Definition.Parameters[3].GetName())
+	require.Equal(t, types.ObjectType{AttrTypes: dmarcRolloutAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestDmarcRolloutFunction_Run(t *testing.T) {
+	rua := map[string]attr.Value{
+		"rua": types.ListValueMust(types.StringType, sliceToValues([]string{"mailto:dmarc@example.com"})),
+	}
+
+	tests := []struct {
+		name         string
+		target       string
+		ramp         string
+		stage        int64
+		config       map[string]attr.Value
+		want         dmarcRolloutResult
+		wantErr      string
+		wantArgument int64
+	}{
+		{
+			name:   "first stage",
+			target: "reject",
+			config: rua,
+			want:   dmarcRolloutResult{stage: 0, stageCount: 5, policy: "none", record: "v=DMARC1; p=none; rua=mailto:dmarc@example.com"},
+		},
+		{
+			name:   "percentage stage",
+			target: "reject",
+			ramp:   "pct",
+			stage:  2,
+			config: rua,
+			want:   dmarcRolloutResult{stage: 2, stageCount: 5, policy: "quarantine", percent: 50, record: "v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.com"},
+		},
+		{
+			name:   "testing stage",
+			target: "reject",
+			ramp:   "t",
+			stage:  3,
+			config: rua,
+			want:   dmarcRolloutResult{stage: 3, stageCount: 5, policy: "reject", testing: true, record: "v=DMARC1; p=reject; t=y; rua=mailto:dmarc@example.com"},
+		},
+		{
+			name:   "past the last stage",
+			target: "quarantine",
+			stage:  10,
+			config: rua,
+			want:   dmarcRolloutResult{stage: 3, stageCount: 4, policy: "quarantine", percent: 100, record: "v=DMARC1; p=quarantine; pct=100; rua=mailto:dmarc@example.com"},
+		},
+		{
+			name:         "invalid target",
+			target:       "block",
+			config:       rua,
+			wantErr:      "invalid DMARC rollout target policy",
+			wantArgument: 0,
+		},
+		{
+			name:         "invalid ramp",
+			target:       "reject",
+			ramp:         "steps",
+			config:       rua,
+			wantErr:      "invalid DMARC rollout ramp",
+			wantArgument: 1,
+		},
+		{
+			name:         "negative stage",
+			target:       "reject",
+			stage:        -1,
+			config:       rua,
+			wantErr:      "rollout stage must not be negative",
+			wantArgument: 2,
+		},
+		{
+			name:         "policy in config",
+			target:       "reject",
+			config:       map[string]attr.Value{"policy": types.StringValue("none")},
+			wantErr:      `attribute "policy": policy is set by the rollout stage`,
+			wantArgument: 3,
+		},
+		{
+			name:         "invalid config attribute",
+			target:       "reject",
+			config:       map[string]attr.Value{"alignment_spf": types.StringValue("x")},
+			wantErr:      `attribute "alignment_spf"`,
+			wantArgument: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewDmarcRolloutFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.target),
+					types.StringValue(tt.ramp),
+					types.Int64Value(tt.stage),
+					objectToDynamic(tt.config),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(dmarcRolloutAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, tt.wantArgument, *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, tt.want.value(), resp.Result)
+		})
+	}
+}
+
+func TestDmarcRolloutScheduleFunction_Metadata(t *testing.T) {
+	f := tffunction.NewDmarcRolloutScheduleFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "dmarc_rollout_schedule", resp.Name)
+}
+
+func TestDmarcRolloutScheduleFunction_Definition(t *testing.T) {
+	f := tffunction.NewDmarcRolloutScheduleFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "DMARC rollout schedule function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 5)
+	require.Equal(t, "schedule", resp.Definition.Parameters[2].GetName())
+	require.Equal(t, "now", resp.Definition.Parameters[3].GetName())
+	require.Equal(t, "config", resp.Definition.Parameters[4].GetName())
+	require.Equal(t, types.ObjectType{AttrTypes: dmarcRolloutAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestDmarcRolloutScheduleFunction_Run(t *testing.T) {
+	schedule := []string{"2026-01-01", "2026-02-01", "2026-03-01T00:00:00Z", "2026-04-01"}
+
+	tests := []struct {
+		name         string
+		schedule     []string
+		now          string
+		want         dmarcRolloutResult
+		wantErr      string
+		wantArgument int64
+	}{
+		{
+			name:     "before the schedule",
+			schedule: schedule,
+			now:      "2025-12-31T23:59:59Z",
+			want:     dmarcRolloutResult{stage: 0, stageCount: 5, policy: "none", record: "v=DMARC1; p=none"},
+		},
+		{
+			name:     "on a stage start",
+			schedule: schedule,
+			now:      "2026-02-01T00:00:00Z",
+			want:     dmarcRolloutResult{stage: 2, stageCount: 5, policy: "quarantine", percent: 50, record: "v=DMARC1; p=quarantine; pct=50"},
+		},
+		{
+			name:     "after the schedule",
+			schedule: schedule,
+			now:      "2026-10-19",
+			want:     dmarcRolloutResult{stage: 4, stageCount: 5, policy: "reject", record: "v=DMARC1; p=reject"},
+		},
+		{
+			name:         "invalid date",
+			schedule:     []string{"2026-01-01", "soon"},
+			now:          "2026-10-19",
+			wantErr:      "schedule[1]: ",
+			wantArgument: 2,
+		},
+		{
+			name:         "unordered dates",
+			schedule:     []string{"2026-02-01", "2026-01-01"},
+			now:          "2026-10-19",
+			wantErr:      "schedule[1]: schedule dates must be in ascending order",
+			wantArgument: 2,
+		},
+		{
+			name:         "too many dates",
+			schedule:     append(schedule, "2026-05-01"),
+			now:          "2026-10-19",
+			wantErr:      "the schedule must have at most 4 dates",
+			wantArgument: 2,
+		},
+		{
+			name:         "invalid now",
+			schedule:     schedule,
+			now:          "today",
+			wantErr:      "cannot parse",
+			wantArgument: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewDmarcRolloutScheduleFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("reject"),
+					types.StringValue("pct"),
+					types.ListValueMust(types.StringType, sliceToValues(tt.schedule)),
+					types.StringValue(tt.now),
+					objectToDynamic(map[string]attr.Value{}),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(dmarcRolloutAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, tt.wantArgument, *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, tt.want.value(), resp.Result)
+		})
+	}
+}
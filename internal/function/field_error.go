@@ -0,0 +1,71 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+// fieldParameter maps a field reported in a fielderror.Error to the name of
+// the function parameter, or object attribute, it was read from.
+type fieldParameter struct {
+	field     string
+	parameter string
+}
+
+// fieldParameterNames returns the parameter names of fields, once each when
+// several fields map to the same parameter.
+func fieldParameterNames(fields []fieldParameter) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !slices.Contains(names, f.parameter) {
+			names = append(names, f.parameter)
+		}
+	}
+	return names
+}
+
+// lookupFieldParameter returns the position and parameter name of the field
+// reported by err, if err is a field error for one of fields.
+func lookupFieldParameter(err error, fields []fieldParameter) (int, string, *fielderror.Error) {
+	var fe *fielderror.Error
+	if !errors.As(err, &fe) {
+		return -1, "", nil
+	}
+	root, rest := fe.Split()
+	for i, f := range fields {
+		if f.field == root {
+			return i, f.parameter + rest, fe
+		}
+	}
+	return -1, "", fe
+}
+
+// fieldArgumentError translates a builder error into a function error for
+// functions taking fields as positional parameters, in the order of fields.
+func fieldArgumentError(err error, fields []fieldParameter) *function.FuncError {
+	i, path, fe := lookupFieldParameter(err, fields)
+	if i < 0 {
+		return function.NewFuncError(err.Error())
+	}
+	if path == fields[i].parameter {
+		return function.NewArgumentFuncError(int64(i), fe.Err.Error())
+	}
+	return function.NewArgumentFuncError(int64(i), fmt.Sprintf("%s: %s", path, fe.Err.Error()))
+}
+
+// fieldAttributeError translates a builder error into a function error for
+// functions taking fields as attributes of the object argument at index.
+func fieldAttributeError(index int64, err error, fields []fieldParameter) *function.FuncError {
+	i, path, fe := lookupFieldParameter(err, fields)
+	if i < 0 {
+		return function.NewArgumentFuncError(index, err.Error())
+	}
+	return function.NewArgumentFuncError(index, fmt.Sprintf("%s: %s", describePath(path), fe.Err.Error()))
+}
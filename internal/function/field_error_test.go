@@ -0,0 +1,127 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+func TestFunction_Run_ArgumentError(t *testing.T) {
+	tests := []struct {
+		name         string
+		function     function.Function
+		args         []attr.Value
+		wantArgument int64
+		wantText     string
+	}{
+		{
+			name:     "dmarc_builder subdomain_policy",
+			function: tffunction.NewDmarcBuilderFunction(),
+			args: []attr.Value{
+				types.StringValue("DMARC1"),
+				types.StringValue("reject"),
+				types.StringValue("invalid"),
+				types.StringValue(""),
+				types.StringValue(""),
+				types.Int32Value(100),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+				types.StringValue(""),
+				types.StringValue(""),
+				types.Int32Value(0),
+			},
+			wantArgument: 2,
+			wantText:     "invalid DMARC subdomain policy",
+		},
+		{
+			name:     "caa_builder issue",
+			function: tffunction.NewCAABuilderFunction(),
+			args: []attr.Value{
+				types.StringValue(""),
+				types.BoolValue(false),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+				types.BoolValue(false),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+				types.BoolValue(false),
+			},
+			wantArgument: 2,
+			wantText:     "CAABuilder requires at least one entry in issue, issuewild, issuemail or issuevmc",
+		},
+		{
+			name:     "caa_builder iodef",
+			function: tffunction.NewCAABuilderFunction(),
+			args: []attr.Value{
+				types.DynamicValue(types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("mailto:security@example.com"), types.StringValue("ftp://example.com")})),
+				types.BoolValue(false),
+				types.ListValueMust(types.StringType, sliceToValues([]string{"letsencrypt.org"})),
+				types.BoolValue(false),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+				types.BoolValue(false),
+			},
+			wantArgument: 0,
+			wantText:     `iodef[1]: invalid iodef URL "ftp://example.com", scheme must be mailto, http or https`,
+		},
+		{
+			name:     "spf_builder txt_max_size",
+			function: tffunction.NewSPFBuilderFunction(),
+			args: []attr.Value{
+				types.StringValue("example.com"),
+				types.StringValue("_spf%d"),
+				types.Int32Value(0),
+				types.BoolValue(false),
+				types.ListValueMust(types.StringType, sliceToValues([]string{"v=spf1", "-all"})),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+			wantArgument: 2,
+			wantText:     "txtMaxSize must be greater than 0",
+		},
+		{
+			name:     "spf_builder overflow",
+			function: tffunction.NewSPFBuilderFunction(),
+			args: []attr.Value{
+				types.StringValue("example.com"),
+				types.StringValue("_spf"),
+				types.Int32Value(255),
+				types.BoolValue(false),
+				types.ListValueMust(types.StringType, sliceToValues([]string{"v=spf1", "-all"})),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+			wantArgument: 1,
+			wantText:     "split format `_spf` in `example.com` is not proper format (missing `%d`)",
+		},
+		{
+			name:     "dmarc_builder_object policy",
+			function: tffunction.NewDmarcBuilderObjectFunction(),
+			args: []attr.Value{
+				objectToDynamic(map[string]attr.Value{
+					"policy": types.StringValue("invalid"),
+				}),
+			},
+			wantArgument: 0,
+			wantText:     `attribute "policy": invalid DMARC policy`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &function.RunResponse{}
+			tt.function.Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData(tt.args),
+			}, resp)
+
+			require.NotNil(t, resp.Error)
+			require.NotNil(t, resp.Error.FunctionArgument)
+			require.Equal(t, tt.wantArgument, *resp.Error.FunctionArgument)
+			require.Equal(t, tt.wantText, resp.Error.Text)
+		})
+	}
+}
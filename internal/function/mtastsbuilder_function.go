@@ -0,0 +1,124 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/mtastsbuilder"
+)
+
+var (
+	_ function.Function = MTASTSBuilderFunction{}
+)
+
+var mtaSTSBuilderFields = []fieldParameter{
+	{"Mode", "mode"},
+	{"MX", "mx"},
+	{"MaxAge", "max_age"},
+	{"Domain", "domain"},
+}
+
+var mtaSTSBuilderAttributeTypes = map[string]attr.Type{
+	"policy":      types.StringType,
+	"id":          types.StringType,
+	"record":      types.StringType,
+	"record_name": types.StringType,
+	"policy_url":  types.StringType,
+}
+
+func NewMTASTSBuilderFunction() function.Function {
+	return MTASTSBuilderFunction{}
+}
+
+type MTASTSBuilderFunction struct{}
+
+func (r MTASTSBuilderFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "mta_sts_builder"
+}
+
+func (r MTASTSBuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "MTA-STS Builder function",
+		MarkdownDescription: "Builds an MTA-STS policy and the TXT record announcing it from an object with the attributes `mode` (`enforce`, `testing` or `none`), `mx`, `max_age` and `domain`. `mx` lists the MX host patterns, host names or wildcards such as `*.mail.example.com` matching a single leftmost label, and may only be empty in `none` mode. `max_age` is the policy lifetime in seconds, at most 31557600 and one week when not set. Returns an object with the `policy` file body, the policy `id`, derived from a hash of the policy so that it changes exactly when the policy does, and the `_mta-sts` TXT `record`. When `domain` is set, `record_name` and the `policy_url` the policy must be served from are also returned, otherwise they are null",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the MTA-STS policy attributes",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: mtaSTSBuilderAttributeTypes,
+		},
+	}
+}
+
+func (r MTASTSBuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, ferr := mtaSTSConfigFromObject(0, value)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	policy, err := mtastsbuilder.MTASTSBuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, mtaSTSBuilderFields))
+		return
+	}
+
+	result := struct {
+		Policy     string  `tfsdk:"policy"`
+		ID         string  `tfsdk:"id"`
+		Record     string  `tfsdk:"record"`
+		RecordName *string `tfsdk:"record_name"`
+		PolicyURL  *string `tfsdk:"policy_url"`
+	}{
+		Policy: policy.Policy,
+		ID:     policy.ID,
+		Record: policy.Record,
+	}
+	if policy.RecordName != "" {
+		result.RecordName = &policy.RecordName
+		result.PolicyURL = &policy.PolicyURL
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+func mtaSTSConfigFromObject(index int64, value types.Dynamic) (mtastsbuilder.MTASTSConfig, *function.FuncError) {
+	var config mtastsbuilder.MTASTSConfig
+
+	o, ferr := newObjectArgument(index, value, fieldParameterNames(mtaSTSBuilderFields)...)
+	if ferr != nil {
+		return config, ferr
+	}
+
+	if config.Mode, ferr = o.String("mode"); ferr != nil {
+		return config, ferr
+	}
+	if config.MX, ferr = o.StringList("mx"); ferr != nil {
+		return config, ferr
+	}
+	maxAge, ferr := o.Int32("max_age")
+	if ferr != nil {
+		return config, ferr
+	}
+	config.MaxAge = int64(maxAge)
+	if config.Domain, ferr = o.String("domain"); ferr != nil {
+		return config, ferr
+	}
+
+	return config, nil
+}
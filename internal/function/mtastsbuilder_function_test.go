@@ -0,0 +1,176 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var mtaSTSBuilderAttributeTypes = map[string]attr.Type{
+	"policy":      types.StringType,
+	"id":          types.StringType,
+	"record":      types.StringType,
+	"record_name": types.StringType,
+	"policy_url":  types.StringType,
+}
+
+func TestMTASTSBuilderFunction_Metadata(t *testing.T) {
+	f := tffunction.NewMTASTSBuilderFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "mta_sts_builder", resp.Name)
+}
+
+func TestMTASTSBuilderFunction_Definition(t *testing.T) {
+	f := tffunction.NewMTASTSBuilderFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "MTA-STS Builder function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: mtaSTSBuilderAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestMTASTSBuilderFunction_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]attr.Value
+		want    map[string]attr.Value
+		wantErr string
+	}{
+		{
+			name: "testing",
+			attrs: map[string]attr.Value{
+				"mode":    types.StringValue("testing"),
+				"mx":      types.ListValueMust(types.StringType, sliceToValues([]string{"mx.example.com"})),
+				"max_age": types.Int64Value(86400),
+			},
+			want: map[string]attr.Value{
+				"policy":      types.StringValue("version: STSv1\r\nmode: testing\r\nmx: mx.example.com\r\nmax_age: 86400\r\n"),
+				"id":          types.StringValue("3fc87e4d8bdc5c648937d50a0c4f5eac"),
+				"record":      types.StringValue("v=STSv1; id=3fc87e4d8bdc5c648937d50a0c4f5eac"),
+				"record_name": types.StringNull(),
+				"policy_url":  types.StringNull(),
+			},
+		},
+		{
+			name: "domain",
+			attrs: map[string]attr.Value{
+				"mode":    types.StringValue("testing"),
+				"mx":      types.ListValueMust(types.StringType, sliceToValues([]string{"mx.example.com"})),
+				"max_age": types.Int64Value(86400),
+				"domain":  types.StringValue("example.com"),
+			},
+			want: map[string]attr.Value{
+				"policy":      types.StringValue("version: STSv1\r\nmode: testing\r\nmx: mx.example.com\r\nmax_age: 86400\r\n"),
+				"id":          types.StringValue("3fc87e4d8bdc5c648937d50a0c4f5eac"),
+				"record":      types.StringValue("v=STSv1; id=3fc87e4d8bdc5c648937d50a0c4f5eac"),
+				"record_name": types.StringValue("_mta-sts.example.com"),
+				"policy_url":  types.StringValue("https://mta-sts.example.com/.well-known/mta-sts.txt"),
+			},
+		},
+		{
+			name: "invalid mx pattern",
+			attrs: map[string]attr.Value{
+				"mode": types.StringValue("enforce"),
+				"mx":   types.ListValueMust(types.StringType, sliceToValues([]string{"mx.example.com", "mx.*.example.com"})),
+			},
+			wantErr: `attribute "mx[1]": invalid MX pattern "mx.*.example.com"`,
+		},
+		{
+			name: "max age too large",
+			attrs: map[string]attr.Value{
+				"mode":    types.StringValue("enforce"),
+				"mx":      types.ListValueMust(types.StringType, sliceToValues([]string{"mx.example.com"})),
+				"max_age": types.Int64Value(31557601),
+			},
+			wantErr: `attribute "max_age": max_age must be between 1 and 31557600 seconds`,
+		},
+		{
+			name: "missing mode",
+			attrs: map[string]attr.Value{
+				"mx": types.ListValueMust(types.StringType, sliceToValues([]string{"mx.example.com"})),
+			},
+			wantErr: `attribute "mode": invalid MTA-STS mode ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewMTASTSBuilderFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(mtaSTSBuilderAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.ObjectValueMust(mtaSTSBuilderAttributeTypes, tt.want)), resp.Result)
+		})
+	}
+}
+
+func TestAccMTASTSBuilderFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+locals {
+  mta_sts = provider::dnshelper::mta_sts_builder({
+    mode    = "testing"
+    mx      = ["mx.example.com"]
+    max_age = 86400
+    domain  = "example.com"
+  })
+}
+
+output "record_name" {
+  value = local.mta_sts.record_name
+}
+
+output "record" {
+  value = local.mta_sts.record
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("record_name", "_mta-sts.example.com"),
+						resource.TestCheckOutput("record", "v=STSv1; id=3fc87e4d8bdc5c648937d50a0c4f5eac"),
+					),
+				},
+			},
+		},
+	)
+}
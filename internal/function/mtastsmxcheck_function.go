@@ -0,0 +1,103 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/mtastsbuilder"
+)
+
+var (
+	_ function.Function = MTASTSMXCheckFunction{}
+)
+
+var mtaSTSMXCheckFields = []fieldParameter{
+	{"Domain", "domain"},
+	{"MX", "mx"},
+	{"Hosts", "mx_hosts"},
+}
+
+var mtaSTSMXCheckAttributeTypes = map[string]attr.Type{
+	"valid":           types.BoolType,
+	"mx_hosts":        types.ListType{ElemType: types.StringType},
+	"mismatches":      types.ListType{ElemType: types.StringType},
+	"unused_patterns": types.ListType{ElemType: types.StringType},
+}
+
+func NewMTASTSMXCheckFunction() function.Function {
+	return MTASTSMXCheckFunction{}
+}
+
+type MTASTSMXCheckFunction struct{}
+
+func (r MTASTSMXCheckFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "mta_sts_mx_check"
+}
+
+func (r MTASTSMXCheckFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "MTA-STS MX check function",
+		MarkdownDescription: "Looks up the MX records of a domain and checks that every MX host matches at least one `mx` pattern of its MTA-STS policy, with the RFC 8461 semantics where a `*.` wildcard matches exactly one leftmost label. Returns an object with `valid`, true when every MX host matches, the `mx_hosts` of the domain, the `mismatches`, hosts sending MTAs enforcing the policy will refuse to deliver to, and the `unused_patterns` no MX host matches, often left over after a mail migration. A domain without MX records, or with a null MX record, is an error. When `mx_hosts` are given they are checked instead of the MX records of `domain`, without any DNS lookup, so the check is deterministic and works offline, e.g. for MX records which are not published yet",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The mail domain to look up the MX records of",
+			},
+			function.ListParameter{
+				ElementType:         types.StringType,
+				Name:                "mx",
+				MarkdownDescription: "The `mx` patterns of the MTA-STS policy, as given to `mta_sts_builder`",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "mx_hosts",
+			MarkdownDescription: "Optional MX host names to check instead of looking up the MX records of `domain`",
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: mtaSTSMXCheckAttributeTypes,
+		},
+	}
+}
+
+func (r MTASTSMXCheckFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+	var mx []string
+	var hosts []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain, &mx, &hosts))
+
+	if resp.Error != nil {
+		return
+	}
+
+	var check mtastsbuilder.MXCheckResult
+	var err error
+	if len(hosts) > 0 {
+		check, err = mtastsbuilder.CheckMXHosts(hosts, mx)
+	} else {
+		check, err = mtastsbuilder.CheckMX(domain, mx, newResolver())
+	}
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, mtaSTSMXCheckFields))
+		return
+	}
+
+	result := struct {
+		Valid          bool     `tfsdk:"valid"`
+		MXHosts        []string `tfsdk:"mx_hosts"`
+		Mismatches     []string `tfsdk:"mismatches"`
+		UnusedPatterns []string `tfsdk:"unused_patterns"`
+	}{
+		Valid:          len(check.Mismatches) == 0,
+		MXHosts:        check.Hosts,
+		Mismatches:     check.Mismatches,
+		UnusedPatterns: check.UnusedPatterns,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
@@ -0,0 +1,190 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var mtaSTSMXCheckAttributeTypes = map[string]attr.Type{
+	"valid":           types.BoolType,
+	"mx_hosts":        types.ListType{ElemType: types.StringType},
+	"mismatches":      types.ListType{ElemType: types.StringType},
+	"unused_patterns": types.ListType{ElemType: types.StringType},
+}
+
+func TestMTASTSMXCheckFunction_Metadata(t *testing.T) {
+	f := tffunction.NewMTASTSMXCheckFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "mta_sts_mx_check", resp.Name)
+}
+
+func TestMTASTSMXCheckFunction_Definition(t *testing.T) {
+	f := tffunction.NewMTASTSMXCheckFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "MTA-STS MX check function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 2)
+	require.Equal(t, "domain", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, "mx", resp.Definition.Parameters[1].GetName())
+	require.Equal(t, types.ListType{ElemType: types.StringType}, resp.Definition.Parameters[1].GetType())
+	require.Equal(t, "mx_hosts", resp.Definition.VariadicParameter.GetName())
+	require.Equal(t, types.ObjectType{AttrTypes: mtaSTSMXCheckAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestMTASTSMXCheckFunction_Run(t *testing.T) {
+	tests := []struct {
+		name           string
+		domain         string
+		mx             []string
+		mxHosts        []string
+		wantValid      bool
+		wantHosts      []string
+		wantMismatches []string
+		wantUnused     []string
+		wantErr        string
+		wantArgument   int64
+	}{
+		{
+			name:           "valid",
+			domain:         "example.com",
+			mx:             []string{"mx1.example.com", "mx2.example.com"},
+			wantValid:      true,
+			wantHosts:      []string{"mx1.example.com", "mx2.example.com"},
+			wantMismatches: []string{},
+			wantUnused:     []string{},
+		},
+		{
+			name:           "mismatches",
+			domain:         "example.org",
+			mx:             []string{"*.mail.protection.outlook.com", "aspmx.l.google.com"},
+			wantHosts:      []string{"example-org.mail.protection.outlook.com", "legacy-mx.example.net"},
+			wantMismatches: []string{"legacy-mx.example.net"},
+			wantUnused:     []string{"aspmx.l.google.com"},
+		},
+		{
+			name:           "mx hosts",
+			domain:         "unpublished.example",
+			mx:             []string{"*.example.com"},
+			mxHosts:        []string{"mx1.example.com", "mx.example.net"},
+			wantHosts:      []string{"mx1.example.com", "mx.example.net"},
+			wantMismatches: []string{"mx.example.net"},
+			wantUnused:     []string{},
+		},
+		{
+			name:         "invalid mx host",
+			domain:       "example.com",
+			mx:           []string{"mx1.example.com"},
+			mxHosts:      []string{"mx1.example.com", "mx 2.example.com"},
+			wantErr:      `mx_hosts[1]: invalid MX host "mx 2.example.com"`,
+			wantArgument: 2,
+		},
+		{
+			name:         "invalid pattern",
+			domain:       "example.com",
+			mx:           []string{"mx1.example.com", "*"},
+			wantErr:      `mx[1]: invalid MX pattern "*"`,
+			wantArgument: 1,
+		},
+		{
+			name:         "null mx",
+			domain:       "nullmx.example.com",
+			mx:           []string{"mx1.example.com"},
+			wantErr:      "nullmx.example.com publishes a null MX record and does not accept mail",
+			wantArgument: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewMTASTSMXCheckFunction()
+
+			hostTypes := make([]attr.Type, len(tt.mxHosts))
+			for i := range hostTypes {
+				hostTypes[i] = types.StringType
+			}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.domain),
+					types.ListValueMust(types.StringType, sliceToValues(tt.mx)),
+					types.TupleValueMust(hostTypes, sliceToValues(tt.mxHosts)),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(mtaSTSMXCheckAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				if tt.wantArgument < 0 {
+					require.Nil(t, resp.Error.FunctionArgument)
+				} else {
+					require.NotNil(t, resp.Error.FunctionArgument)
+					require.Equal(t, tt.wantArgument, *resp.Error.FunctionArgument)
+				}
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(mtaSTSMXCheckAttributeTypes, map[string]attr.Value{
+				"valid":           types.BoolValue(tt.wantValid),
+				"mx_hosts":        types.ListValueMust(types.StringType, sliceToValues(tt.wantHosts)),
+				"mismatches":      types.ListValueMust(types.StringType, sliceToValues(tt.wantMismatches)),
+				"unused_patterns": types.ListValueMust(types.StringType, sliceToValues(tt.wantUnused)),
+			})
+			require.Equal(t, function.NewResultData(want), resp.Result)
+		})
+	}
+}
+
+func TestAccMTASTSMXCheckFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "mismatches" {
+  value = length(provider::dnshelper::mta_sts_mx_check("gmail.com", ["gmail-smtp-in.l.google.com", "*.gmail-smtp-in.l.google.com"]).mismatches)
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("mismatches", "0"),
+					),
+				},
+				{
+					Config: `
+output "mismatches" {
+  value = join(",", provider::dnshelper::mta_sts_mx_check("example.com", ["*.example.com"], "mx1.example.com", "mx.example.net").mismatches)
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("mismatches", "mx.example.net"),
+					),
+				},
+			},
+		},
+	)
+}
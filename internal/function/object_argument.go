@@ -0,0 +1,268 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// objectArgument decodes a dynamic argument holding an object whose
+// attributes are all optional, which object parameters cannot express.
+type objectArgument struct {
+	index int64
+	path  string
+	attrs map[string]attr.Value
+}
+
+func newObjectArgument(index int64, value types.Dynamic, allowed ...string) (*objectArgument, *function.FuncError) {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return nil, function.NewArgumentFuncError(index, "argument must be an object, got null")
+	}
+
+	return decodeObject(index, "", value.UnderlyingValue(), allowed...)
+}
+
+func decodeObject(index int64, path string, value attr.Value, allowed ...string) (*objectArgument, *function.FuncError) {
+	var attrs map[string]attr.Value
+	switch v := value.(type) {
+	case basetypes.ObjectValue:
+		attrs = v.Attributes()
+	case basetypes.MapValue:
+		attrs = v.Elements()
+	case basetypes.DynamicValue:
+		return decodeObject(index, path, v.UnderlyingValue(), allowed...)
+	default:
+		return nil, function.NewArgumentFuncError(index, fmt.Sprintf("%s must be an object", describePath(path)))
+	}
+
+	o := &objectArgument{index: index, path: path, attrs: attrs}
+
+	known := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		known[name] = true
+	}
+	var unknown []string
+	for name := range attrs {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, o.errorf(unknown[0], "unsupported attribute, expected one of: %s", strings.Join(allowed, ", "))
+	}
+
+	return o, nil
+}
+
+// errorf returns an argument error prefixed with the path of the attribute.
+func (o *objectArgument) errorf(name string, format string, a ...any) *function.FuncError {
+	return function.NewArgumentFuncError(o.index, fmt.Sprintf("%s: %s", describePath(o.attributePath(name)), fmt.Sprintf(format, a...)))
+}
+
+func (o *objectArgument) attributePath(name string) string {
+	if o.path == "" {
+		return name
+	}
+	return o.path + "." + name
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "argument"
+	}
+	return fmt.Sprintf("attribute %q", path)
+}
+
+// lookup returns the attribute value, or nil when it is absent or null.
+func (o *objectArgument) lookup(name string) attr.Value {
+	v, ok := o.attrs[name]
+	if !ok || v == nil || v.IsNull() {
+		return nil
+	}
+	if d, ok := v.(basetypes.DynamicValue); ok {
+		if d.IsUnderlyingValueNull() {
+			return nil
+		}
+		return d.UnderlyingValue()
+	}
+	return v
+}
+
+func (o *objectArgument) String(name string) (string, *function.FuncError) {
+	v := o.lookup(name)
+	if v == nil {
+		return "", nil
+	}
+	s, ok := v.(basetypes.StringValue)
+	if !ok {
+		return "", o.errorf(name, "must be a string")
+	}
+	return s.ValueString(), nil
+}
+
+func (o *objectArgument) Bool(name string) (bool, *function.FuncError) {
+	v := o.lookup(name)
+	if v == nil {
+		return false, nil
+	}
+	b, ok := v.(basetypes.BoolValue)
+	if !ok {
+		return false, o.errorf(name, "must be a bool")
+	}
+	return b.ValueBool(), nil
+}
+
+func (o *objectArgument) Int32(name string) (int32, *function.FuncError) {
+	v := o.lookup(name)
+	if v == nil {
+		return 0, nil
+	}
+
+	var f *big.Float
+	switch n := v.(type) {
+	case basetypes.NumberValue:
+		f = n.ValueBigFloat()
+	case basetypes.Int64Value:
+		f = new(big.Float).SetInt64(n.ValueInt64())
+	case basetypes.Int32Value:
+		f = new(big.Float).SetInt64(int64(n.ValueInt32()))
+	default:
+		return 0, o.errorf(name, "must be a number")
+	}
+
+	i, accuracy := f.Int64()
+	if accuracy != big.Exact || i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, o.errorf(name, "must be a whole number between %d and %d", math.MinInt32, math.MaxInt32)
+	}
+	return int32(i), nil
+}
+
+// elements returns the elements of a list, set or tuple attribute.
+func (o *objectArgument) elements(name string) ([]attr.Value, *function.FuncError) {
+	v := o.lookup(name)
+	if v == nil {
+		return nil, nil
+	}
+	switch l := v.(type) {
+	case basetypes.ListValue:
+		return l.Elements(), nil
+	case basetypes.SetValue:
+		return l.Elements(), nil
+	case basetypes.TupleValue:
+		return l.Elements(), nil
+	default:
+		return nil, o.errorf(name, "must be a list")
+	}
+}
+
+func (o *objectArgument) StringList(name string) ([]string, *function.FuncError) {
+	elements, ferr := o.elements(name)
+	if ferr != nil || elements == nil {
+		return nil, ferr
+	}
+
+	r := make([]string, 0, len(elements))
+	for i, e := range elements {
+		if d, ok := e.(basetypes.DynamicValue); ok {
+			e = d.UnderlyingValue()
+		}
+		s, ok := e.(basetypes.StringValue)
+		if !ok || s.IsNull() {
+			return nil, o.errorf(fmt.Sprintf("%s[%d]", name, i), "must be a string")
+		}
+		r = append(r, s.ValueString())
+	}
+	return r, nil
+}
+
+// StringMap returns a map or object attribute whose values are all strings.
+func (o *objectArgument) StringMap(name string) (map[string]string, *function.FuncError) {
+	v := o.lookup(name)
+	if v == nil {
+		return nil, nil
+	}
+
+	var elements map[string]attr.Value
+	switch m := v.(type) {
+	case basetypes.MapValue:
+		elements = m.Elements()
+	case basetypes.ObjectValue:
+		elements = m.Attributes()
+	default:
+		return nil, o.errorf(name, "must be a map of strings")
+	}
+
+	r := make(map[string]string, len(elements))
+	for k, e := range elements {
+		if d, ok := e.(basetypes.DynamicValue); ok {
+			e = d.UnderlyingValue()
+		}
+		s, ok := e.(basetypes.StringValue)
+		if !ok || s.IsNull() {
+			return nil, o.errorf(fmt.Sprintf("%s[%q]", name, k), "must be a string")
+		}
+		r[k] = s.ValueString()
+	}
+	return r, nil
+}
+
+// StringOrList returns a string attribute as a single element list, or the
+// elements of a list of strings. An empty string yields no elements.
+func (o *objectArgument) StringOrList(name string) ([]string, *function.FuncError) {
+	v := o.lookup(name)
+	if v == nil {
+		return nil, nil
+	}
+	r, ok := stringOrList(v)
+	if !ok {
+		return nil, o.errorf(name, "must be a string or a list of strings")
+	}
+	return r, nil
+}
+
+func stringOrList(v attr.Value) ([]string, bool) {
+	if d, ok := v.(basetypes.DynamicValue); ok {
+		v = d.UnderlyingValue()
+	}
+
+	var elements []attr.Value
+	switch l := v.(type) {
+	case basetypes.StringValue:
+		if l.ValueString() == "" {
+			return nil, true
+		}
+		return []string{l.ValueString()}, true
+	case basetypes.ListValue:
+		elements = l.Elements()
+	case basetypes.SetValue:
+		elements = l.Elements()
+	case basetypes.TupleValue:
+		elements = l.Elements()
+	default:
+		return nil, false
+	}
+
+	r := make([]string, 0, len(elements))
+	for _, e := range elements {
+		if d, ok := e.(basetypes.DynamicValue); ok {
+			e = d.UnderlyingValue()
+		}
+		s, ok := e.(basetypes.StringValue)
+		if !ok || s.IsNull() {
+			return nil, false
+		}
+		r = append(r, s.ValueString())
+	}
+	return r, true
+}
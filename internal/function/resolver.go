@@ -0,0 +1,31 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dnsresolver"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/testutil"
+)
+
+// resolver is the set of lookups functions need, satisfied by both
+// dnsresolver.LiveResolver and testutil.MockResolver.
+type resolver interface {
+	GetTXT(domain string) ([]string, error)
+	GetCAA(name string) ([]string, error)
+	GetCNAME(name string) (string, error)
+	GetMX(name string) ([]string, error)
+}
+
+// newResolver returns the resolver used by functions that look up records,
+// serving the testdata records under unit tests like buildSPFRecord does.
+func newResolver() resolver {
+	if testing.Testing() && !strings.HasPrefix(os.Getenv("TF_ACC"), "1") {
+		return testutil.NewMockDNSResolver()
+	}
+	return dnsresolver.LiveResolver{}
+}
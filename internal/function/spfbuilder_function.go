@@ -5,7 +5,6 @@ package function
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -20,6 +19,17 @@ var (
 	_ function.Function = SPFBuilderFunction{}
 )
 
+// spfBuilderFields maps the fields reported by spfbuilder errors to
+// spf_builder parameters, in parameter order.
+var spfBuilderFields = []fieldParameter{
+	{"Domain", "domain"},
+	{"Overflow", "overflow"},
+	{"TxtMaxSize", "txt_max_size"},
+	{"DomainOnRecordKey", "domain_on_record_key"},
+	{"Parts", "parts"},
+	{"Flatten", "flatten"},
+}
+
 func NewSPFBuilderFunction() function.Function {
 	return SPFBuilderFunction{}
 }
@@ -81,13 +91,12 @@ func (r SPFBuilderFunction) Run(ctx context.Context, req function.RunRequest, re
 	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data.Domain, &data.Overflow, &data.TxtMaxSize, &data.DomainOnRecordKey, &data.Parts, &data.Flatten))
 
 	if resp.Error != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Errorf("failed to get arguments").Error()))
 		return
 	}
 
 	result, err := buildSPFRecord(data.Domain, data.Overflow, data.TxtMaxSize, data.DomainOnRecordKey, data.Parts, data.Flatten)
 	if err != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldArgumentError(err, spfBuilderFields))
 		return
 	}
 
@@ -0,0 +1,81 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/sshfpbuilder"
+)
+
+var (
+	_ function.Function = SSHFPBuilderFunction{}
+)
+
+var sshfpBuilderFields = []fieldParameter{
+	{"PublicKeys", "public_keys"},
+	{"HashTypes", "hash_types"},
+}
+
+func NewSSHFPBuilderFunction() function.Function {
+	return SSHFPBuilderFunction{}
+}
+
+type SSHFPBuilderFunction struct{}
+
+func (r SSHFPBuilderFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sshfp_builder"
+}
+
+func (r SSHFPBuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "SSHFP Builder function",
+		MarkdownDescription: "Builds SSHFP records from an object with the attributes `public_keys` (required) and `hash_types`. `public_keys` is an OpenSSH public key line, as found in host key `.pub` files, or a list of them; RSA, DSA, ECDSA and Ed25519 keys are supported. `hash_types` lists the fingerprint types published for each key, `sha1` and `sha256`, and defaults to both, like `ssh-keygen -r`. Returns the list of record values, `<algorithm> <fingerprint type> <hex fingerprint>`, in key order",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the SSHFP record attributes",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (r SSHFPBuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	o, ferr := newObjectArgument(0, value, fieldParameterNames(sshfpBuilderFields)...)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	var config sshfpbuilder.SSHFPConfig
+	if config.PublicKeys, ferr = o.StringOrList("public_keys"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if config.HashTypes, ferr = o.StringList("hash_types"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	result, err := sshfpbuilder.SSHFPBuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, sshfpBuilderFields))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
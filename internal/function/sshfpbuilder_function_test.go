@@ -0,0 +1,151 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+const (
+	sshfpEd25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMTgPWPnkCyL6iTW3gZ+DTLBwW48vPLMnsemqrh4hlYl root@host"
+	sshfpECDSAKey   = "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBIAPo+TmvXbmCFMclG7f11kttPB9BrGdGQgICtsvD+eIygu6c0pxN3Zya0/OK8fGz4580XaGFidDoe9cqOz4dNo= root@host"
+)
+
+func TestSSHFPBuilderFunction_Metadata(t *testing.T) {
+	f := tffunction.NewSSHFPBuilderFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "sshfp_builder", resp.Name)
+}
+
+func TestSSHFPBuilderFunction_Definition(t *testing.T) {
+	f := tffunction.NewSSHFPBuilderFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "SSHFP Builder function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ListType{ElemType: types.StringType}, resp.Definition.Return.GetType())
+}
+
+func TestSSHFPBuilderFunction_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]attr.Value
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "single key",
+			attrs: map[string]attr.Value{
+				"public_keys": types.StringValue(sshfpEd25519Key + "\n"),
+			},
+			want: []string{
+				"4 1 9355da1d6f33d16d6ed89b464658be015233a1d6",
+				"4 2 60ac760bf0a39043fbc028c21f1ab5c09fe48bdd3631a157a0140397f1382e46",
+			},
+		},
+		{
+			name: "keys and hash types",
+			attrs: map[string]attr.Value{
+				"public_keys": types.ListValueMust(types.StringType, sliceToValues([]string{sshfpECDSAKey, sshfpEd25519Key})),
+				"hash_types":  types.ListValueMust(types.StringType, sliceToValues([]string{"sha256"})),
+			},
+			want: []string{
+				"3 2 deecdf84b78cc89f411e0a02d599b1db3bf8bd039cea4cd7dc2ee05a30c5c656",
+				"4 2 60ac760bf0a39043fbc028c21f1ab5c09fe48bdd3631a157a0140397f1382e46",
+			},
+		},
+		{
+			name: "invalid hash type",
+			attrs: map[string]attr.Value{
+				"public_keys": types.StringValue(sshfpEd25519Key),
+				"hash_types":  types.ListValueMust(types.StringType, sliceToValues([]string{"md5"})),
+			},
+			wantErr: `attribute "hash_types[0]": invalid SSHFP fingerprint type "md5", must be sha1 or sha256`,
+		},
+		{
+			name: "invalid key",
+			attrs: map[string]attr.Value{
+				"public_keys": types.ListValueMust(types.StringType, sliceToValues([]string{sshfpEd25519Key, "not a key"})),
+			},
+			wantErr: `attribute "public_keys[1]": invalid OpenSSH public key`,
+		},
+		{
+			name:    "missing keys",
+			attrs:   map[string]attr.Value{},
+			wantErr: `attribute "public_keys": at least one public key is required`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewSSHFPBuilderFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, function.NewResultData(types.ListValueMust(types.StringType, sliceToValues(tt.want))), resp.Result)
+		})
+	}
+}
+
+func TestAccSSHFPBuilderFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output_jsonencode" {
+  value = jsonencode(provider::dnshelper::sshfp_builder({
+    public_keys = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMTgPWPnkCyL6iTW3gZ+DTLBwW48vPLMnsemqrh4hlYl root@host"
+    hash_types  = ["sha256"]
+  }))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output_jsonencode",
+							`["4 2 60ac760bf0a39043fbc028c21f1ab5c09fe48bdd3631a157a0140397f1382e46"]`,
+						),
+					),
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,143 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/tlsabuilder"
+)
+
+var (
+	_ function.Function = TLSABuilderFunction{}
+)
+
+var tlsaBuilderFields = []fieldParameter{
+	{"Certificate", "certificate"},
+	{"Usage", "usage"},
+	{"Selector", "selector"},
+	{"MatchingType", "matching_type"},
+	{"Port", "port"},
+	{"Protocol", "protocol"},
+	{"Host", "host"},
+}
+
+var tlsaBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+}
+
+func NewTLSABuilderFunction() function.Function {
+	return TLSABuilderFunction{}
+}
+
+type TLSABuilderFunction struct{}
+
+func (r TLSABuilderFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tlsa_builder"
+}
+
+func (r TLSABuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "TLSA Builder function",
+		MarkdownDescription: "Builds a DANE TLSA record from an object with the attributes `certificate` (required), `usage`, `selector`, `matching_type`, `port`, `protocol` and `host`. `certificate` is a single PEM certificate, or a PEM public key when `selector` is 1. `usage` is the certificate usage from 0 (PKIX-TA) to 3 (DANE-EE), `selector` is 0 to match the full certificate or 1 its public key and `matching_type` is 0 for the data itself, 1 for its SHA-256 or 2 for its SHA-512 hash; they default to 3, 1 and 1, the combination recommended for SMTP by RFC 7672. `port` defaults to 25 and `protocol`, `tcp`, `udp` or `sctp`, to `tcp`. The association data is computed locally, without any network access. Returns an object with the record `name`, `_<port>._<protocol>` followed by `host` when set, and the `record` value, `<usage> <selector> <matching_type> <hex data>`",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the TLSA record attributes",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: tlsaBuilderAttributeTypes,
+		},
+	}
+}
+
+func (r TLSABuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	o, ferr := newObjectArgument(0, value, fieldParameterNames(tlsaBuilderFields)...)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	var config tlsabuilder.TLSAConfig
+	var protocol, host string
+	if config.Certificate, ferr = o.String("certificate"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	usage, ferr := int32OrDefault(o, "usage", tlsabuilder.UsageDANEEE)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	selector, ferr := int32OrDefault(o, "selector", tlsabuilder.SelectorSPKI)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	matchingType, ferr := int32OrDefault(o, "matching_type", tlsabuilder.MatchingSHA256)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	port, ferr := int32OrDefault(o, "port", 25)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if protocol, ferr = o.String("protocol"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	if host, ferr = o.String("host"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	config.Usage, config.Selector, config.MatchingType = int(usage), int(selector), int(matchingType)
+
+	name, err := tlsabuilder.RecordName(int(port), protocol, host)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, tlsaBuilderFields))
+		return
+	}
+
+	record, err := tlsabuilder.TLSABuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, tlsaBuilderFields))
+		return
+	}
+
+	result := struct {
+		Name   string `tfsdk:"name"`
+		Record string `tfsdk:"record"`
+	}{
+		Name:   name,
+		Record: record,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
+
+// int32OrDefault returns the number attribute name, or def when it is not set.
+func int32OrDefault(o *objectArgument, name string, def int32) (int32, *function.FuncError) {
+	if o.lookup(name) == nil {
+		return def, nil
+	}
+	return o.Int32(name)
+}
@@ -0,0 +1,176 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var tlsaBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+}
+
+const tlsaCertificate = `-----BEGIN CERTIFICATE-----
+MIIBiTCCAS+gAwIBAgIUcBIBUMdDYjJblUXDqae6GdyAyekwCgYIKoZIzj0EAwIw
+GTEXMBUGA1UEAwwObXguZXhhbXBsZS5jb20wIBcNMjYxMDE5MDIzNDM0WhgPMjEy
+NjA5MjUwMjM0MzRaMBkxFzAVBgNVBAMMDm14LmV4YW1wbGUuY29tMFkwEwYHKoZI
+zj0CAQYIKoZIzj0DAQcDQgAEZbGYnUGhd+NWIR1n0E9A/OE2hJzNpz3ygqIT4CRQ
+HbK89LJcCHl0FrPu/vQkpCvmKIUG6ZhNWMdc5ySB1UdkO6NTMFEwHQYDVR0OBBYE
+FBuDgKApThhFT89KpLtx8FQR4oJDMB8GA1UdIwQYMBaAFBuDgKApThhFT89KpLtx
+8FQR4oJDMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDSAAwRQIhAM6gi8s3
+xhBvTdlRJ/Z9Cbz9zN7uaRaOwwLLR1SY/z4OAiAToveJvsxJf8Tzf1E0Wmags/Tr
+xzb9i3e4kpTPVgaaJw==
+-----END CERTIFICATE-----
+`
+
+func TestTLSABuilderFunction_Metadata(t *testing.T) {
+	f := tffunction.NewTLSABuilderFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "tlsa_builder", resp.Name)
+}
+
+func TestTLSABuilderFunction_Definition(t *testing.T) {
+	f := tffunction.NewTLSABuilderFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "TLSA Builder function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: tlsaBuilderAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestTLSABuilderFunction_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]attr.Value
+		wantName   string
+		wantRecord string
+		wantErr    string
+	}{
+		{
+			name: "defaults",
+			attrs: map[string]attr.Value{
+				"certificate": types.StringValue(tlsaCertificate),
+			},
+			wantName:   "_25._tcp",
+			wantRecord: "3 1 1 b0972a2ad7519b594a07ff7190d5625f9d4323190276b1f49bdef82cb4af3297",
+		},
+		{
+			name: "full certificate",
+			attrs: map[string]attr.Value{
+				"certificate":   types.StringValue(tlsaCertificate),
+				"usage":         types.NumberValue(big.NewFloat(2)),
+				"selector":      types.NumberValue(big.NewFloat(0)),
+				"matching_type": types.NumberValue(big.NewFloat(2)),
+				"port":          types.NumberValue(big.NewFloat(443)),
+				"host":          types.StringValue("www.example.com"),
+			},
+			wantName:   "_443._tcp.www.example.com",
+			wantRecord: "2 0 2 3dab7fbb9d007260e7b5d2331b5f453d212c6739bda80f6eeaafda5f2787a5949adcb8eeea63952aa4560098d1fa02632ae88e98945c9a95e0b2fa8e4a127e61",
+		},
+		{
+			name: "invalid usage",
+			attrs: map[string]attr.Value{
+				"certificate": types.StringValue(tlsaCertificate),
+				"usage":       types.NumberValue(big.NewFloat(4)),
+			},
+			wantErr: `attribute "usage": invalid TLSA certificate usage 4, must be 0 to 3`,
+		},
+		{
+			name: "invalid protocol",
+			attrs: map[string]attr.Value{
+				"certificate": types.StringValue(tlsaCertificate),
+				"protocol":    types.StringValue("icmp"),
+			},
+			wantErr: `attribute "protocol": invalid protocol "icmp", must be tcp, udp or sctp`,
+		},
+		{
+			name:    "missing certificate",
+			attrs:   map[string]attr.Value{},
+			wantErr: `attribute "certificate": certificate must not be empty`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewTLSABuilderFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(tlsaBuilderAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(tlsaBuilderAttributeTypes, map[string]attr.Value{
+				"name":   types.StringValue(tt.wantName),
+				"record": types.StringValue(tt.wantRecord),
+			})
+			require.Equal(t, function.NewResultData(want), resp.Result)
+		})
+	}
+}
+
+func TestAccTLSABuilderFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output_jsonencode" {
+  value = jsonencode(provider::dnshelper::tlsa_builder({
+    certificate = <<-EOT
+      -----BEGIN PUBLIC KEY-----
+      MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEZbGYnUGhd+NWIR1n0E9A/OE2hJzN
+      pz3ygqIT4CRQHbK89LJcCHl0FrPu/vQkpCvmKIUG6ZhNWMdc5ySB1UdkOw==
+      -----END PUBLIC KEY-----
+    EOT
+    host        = "mx.malmeida.dev"
+  }))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output_jsonencode",
+							`{"name":"_25._tcp.mx.malmeida.dev","record":"3 1 1 b0972a2ad7519b594a07ff7190d5625f9d4323190276b1f49bdef82cb4af3297"}`,
+						),
+					),
+				},
+			},
+		},
+	)
+}
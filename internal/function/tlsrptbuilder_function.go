@@ -0,0 +1,107 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/tlsrptbuilder"
+)
+
+var (
+	_ function.Function = TLSRPTBuilderFunction{}
+)
+
+var tlsRPTBuilderFields = []fieldParameter{
+	{"Version", "version"},
+	{"RUA", "rua"},
+	{"Domain", "domain"},
+}
+
+var tlsRPTBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+}
+
+func NewTLSRPTBuilderFunction() function.Function {
+	return TLSRPTBuilderFunction{}
+}
+
+type TLSRPTBuilderFunction struct{}
+
+func (r TLSRPTBuilderFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tlsrpt_builder"
+}
+
+func (r TLSRPTBuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "TLS-RPT Builder function",
+		MarkdownDescription: "Builds an SMTP TLS Reporting (RFC 8460) record from an object with the attributes `rua` (required), `version` and `domain`. `rua` lists the report destinations, each a `mailto:` URI with a single address or an absolute `https:` URL, in which commas and semicolons must be percent-encoded. `version` defaults to `TLSRPTv1`. Returns an object with the record `name`, `_smtp._tls` followed by `domain` when set, and the `record` value",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "config",
+				MarkdownDescription: "Object with the TLS-RPT record attributes",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: tlsRPTBuilderAttributeTypes,
+		},
+	}
+}
+
+func (r TLSRPTBuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	o, ferr := newObjectArgument(0, value, fieldParameterNames(tlsRPTBuilderFields)...)
+	if ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	var config tlsrptbuilder.TLSRPTConfig
+	var domain string
+	if config.Version, ferr = o.String("version"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if config.RUA, ferr = o.StringList("rua"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+	if domain, ferr = o.String("domain"); ferr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, ferr)
+		return
+	}
+
+	name, err := tlsrptbuilder.RecordName(domain)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, tlsRPTBuilderFields))
+		return
+	}
+
+	record, err := tlsrptbuilder.TLSRPTBuilder(config)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, fieldAttributeError(0, err, tlsRPTBuilderFields))
+		return
+	}
+
+	result := struct {
+		Name   string `tfsdk:"name"`
+		Record string `tfsdk:"record"`
+	}{
+		Name:   name,
+		Record: record,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &result))
+}
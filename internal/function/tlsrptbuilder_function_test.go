@@ -0,0 +1,144 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/stretchr/testify/require"
+
+	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
+)
+
+var tlsRPTBuilderAttributeTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"record": types.StringType,
+}
+
+func TestTLSRPTBuilderFunction_Metadata(t *testing.T) {
+	f := tffunction.NewTLSRPTBuilderFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+	require.Equal(t, "tlsrpt_builder", resp.Name)
+}
+
+func TestTLSRPTBuilderFunction_Definition(t *testing.T) {
+	f := tffunction.NewTLSRPTBuilderFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+	require.Equal(t, "TLS-RPT Builder function", resp.Definition.Summary)
+	require.Len(t, resp.Definition.Parameters, 1)
+	require.Equal(t, "config", resp.Definition.Parameters[0].GetName())
+	require.Equal(t, types.DynamicType, resp.Definition.Parameters[0].GetType())
+	require.Equal(t, types.ObjectType{AttrTypes: tlsRPTBuilderAttributeTypes}, resp.Definition.Return.GetType())
+}
+
+func TestTLSRPTBuilderFunction_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]attr.Value
+		wantName   string
+		wantRecord string
+		wantErr    string
+	}{
+		{
+			name: "relative name",
+			attrs: map[string]attr.Value{
+				"rua": types.ListValueMust(types.StringType, sliceToValues([]string{"mailto:tlsrpt@example.com", "https://reporting.example.com/tlsrpt"})),
+			},
+			wantName:   "_smtp._tls",
+			wantRecord: "v=TLSRPTv1; rua=mailto:tlsrpt@example.com,https://reporting.example.com/tlsrpt",
+		},
+		{
+			name: "domain",
+			attrs: map[string]attr.Value{
+				"rua":    types.TupleValueMust([]attr.Type{types.StringType}, sliceToValues([]string{"mailto:tlsrpt@example.com"})),
+				"domain": types.StringValue("example.com"),
+			},
+			wantName:   "_smtp._tls.example.com",
+			wantRecord: "v=TLSRPTv1; rua=mailto:tlsrpt@example.com",
+		},
+		{
+			name: "invalid destination",
+			attrs: map[string]attr.Value{
+				"rua": types.ListValueMust(types.StringType, sliceToValues([]string{"mailto:tlsrpt@example.com", "http://reporting.example.com/tlsrpt"})),
+			},
+			wantErr: `attribute "rua[1]": invalid report destination "http://reporting.example.com/tlsrpt", must be a mailto: or https: URI`,
+		},
+		{
+			name:    "missing destinations",
+			attrs:   map[string]attr.Value{},
+			wantErr: `attribute "rua": at least one report destination is required`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tffunction.NewTLSRPTBuilderFunction()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{objectToDynamic(tt.attrs)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(tlsRPTBuilderAttributeTypes)),
+			}
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				require.Contains(t, resp.Error.Text, tt.wantErr)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(tlsRPTBuilderAttributeTypes, map[string]attr.Value{
+				"name":   types.StringValue(tt.wantName),
+				"record": types.StringValue(tt.wantRecord),
+			})
+			require.Equal(t, function.NewResultData(want), resp.Result)
+		})
+	}
+}
+
+func TestAccTLSRPTBuilderFunction_tf(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+
+	resource.UnitTest(
+		t,
+		resource.TestCase{
+			ProtoV6ProviderFactories: provider.ProtoV6ProviderFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(version.Must(version.NewVersion("1.8.2"))),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: `
+output "valid_output_jsonencode" {
+  value = jsonencode(provider::dnshelper::tlsrpt_builder({
+    rua    = ["mailto:tlsrpt@malmeida.dev"]
+    domain = "malmeida.dev"
+  }))
+}
+`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput(
+							"valid_output_jsonencode",
+							`{"name":"_smtp._tls.malmeida.dev","record":"v=TLSRPTv1; rua=mailto:tlsrpt@malmeida.dev"}`,
+						),
+					),
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,184 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dkimbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/fielderror"
+)
+
+var _ ephemeral.EphemeralResource = &DKIMKeypairEphemeralResource{}
+
+// dkimKeypairAttributes maps the fields reported by dkimbuilder errors to the
+// attributes they were read from.
+var dkimKeypairAttributes = map[string]string{
+	"KeyType":  "key_type",
+	"Bits":     "rsa_bits",
+	"Selector": "selector",
+	"Domain":   "domain",
+	"Flags":    "flags",
+}
+
+func NewDKIMKeypairEphemeralResource() ephemeral.EphemeralResource {
+	return &DKIMKeypairEphemeralResource{}
+}
+
+type DKIMKeypairEphemeralResource struct{}
+
+type DKIMKeypairEphemeralResourceModel struct {
+	KeyType       types.String `tfsdk:"key_type"`
+	RSABits       types.Int32  `tfsdk:"rsa_bits"`
+	Selector      types.String `tfsdk:"selector"`
+	Domain        types.String `tfsdk:"domain"`
+	Flags         types.List   `tfsdk:"flags"`
+	PrivateKeyPEM types.String `tfsdk:"private_key_pem"`
+	PublicKeyPEM  types.String `tfsdk:"public_key_pem"`
+	PublicKey     types.String `tfsdk:"public_key"`
+	RecordName    types.String `tfsdk:"record_name"`
+	Record        types.String `tfsdk:"record"`
+	Chunks        types.List   `tfsdk:"chunks"`
+}
+
+func (r *DKIMKeypairEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dkim_keypair"
+}
+
+func (r *DKIMKeypairEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a DKIM key pair in memory along with the DKIM key record publishing its public key. As an ephemeral resource, the private key is never persisted to the plan or state, so it can be passed to a secret store or to the MTA configuration during apply. A new key pair is generated every time the ephemeral resource is opened, so the record must be published from the same run the private key is stored from.",
+		Attributes: map[string]schema.Attribute{
+			"key_type": schema.StringAttribute{
+				MarkdownDescription: "The key type, `rsa` (the default) or `ed25519`",
+				Optional:            true,
+			},
+			"rsa_bits": schema.Int32Attribute{
+				MarkdownDescription: "The RSA key size, 2048 (the default) or 4096. Must not be set for `ed25519` keys",
+				Optional:            true,
+			},
+			"selector": schema.StringAttribute{
+				MarkdownDescription: "The DKIM selector, used to compute `record_name`",
+				Optional:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The signing domain appended to `record_name`",
+				Optional:            true,
+			},
+			"flags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The flags (t=) of the DKIM record: `y` for testing mode and `s` to forbid subdomains in the i= signature tag",
+				Optional:            true,
+			},
+			"private_key_pem": schema.StringAttribute{
+				MarkdownDescription: "The private key, PEM encoded in PKCS #8 format",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"public_key_pem": schema.StringAttribute{
+				MarkdownDescription: "The public key, PEM encoded in PKIX format",
+				Computed:            true,
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key as published in the p= tag",
+				Computed:            true,
+			},
+			"record_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the DKIM record, `<selector>._domainkey` followed by `domain` when set. Null when `selector` is not set",
+				Computed:            true,
+			},
+			"record": schema.StringAttribute{
+				MarkdownDescription: "The DKIM record value",
+				Computed:            true,
+			},
+			"chunks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The DKIM record value split into 255 byte strings, for DNS providers that require TXT strings to be split",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DKIMKeypairEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data DKIMKeypairEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyType := data.KeyType.ValueString()
+	if keyType == "" {
+		keyType = "rsa"
+	}
+
+	config := dkimbuilder.DKIMConfig{KeyType: keyType}
+	if !data.Flags.IsNull() {
+		resp.Diagnostics.Append(data.Flags.ElementsAs(ctx, &config.Flags, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Selector.IsNull() {
+		name, err := dkimbuilder.RecordName(data.Selector.ValueString(), data.Domain.ValueString())
+		if err != nil {
+			r.addError(&resp.Diagnostics, err)
+			return
+		}
+		data.RecordName = types.StringValue(name)
+	} else {
+		data.RecordName = types.StringNull()
+	}
+
+	keyPair, err := dkimbuilder.GenerateKeyPair(keyType, int(data.RSABits.ValueInt32()))
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	config.PublicKey = keyPair.PublicKey.String()
+
+	record, err := dkimbuilder.DKIMBuilder(config)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.PrivateKeyPEM = types.StringValue(keyPair.PrivateKeyPEM)
+	data.PublicKeyPEM = types.StringValue(keyPair.PublicKeyPEM)
+	data.PublicKey = types.StringValue(config.PublicKey)
+	data.Record = types.StringValue(record)
+
+	chunks, diags := types.ListValueFrom(ctx, types.StringType, dkimbuilder.SplitTXT(record, dkimbuilder.TXTStringSize))
+	resp.Diagnostics.Append(diags...)
+	data.Chunks = chunks
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// addError reports err against the attribute it was caused by, if any.
+func (r *DKIMKeypairEphemeralResource) addError(diags *diag.Diagnostics, err error) {
+	var fe *fielderror.Error
+	if errors.As(err, &fe) {
+		root, _ := fe.Split()
+		if name, ok := dkimKeypairAttributes[root]; ok {
+			diags.AddAttributeError(path.Root(name), "Invalid DKIM key pair configuration", fe.Err.Error())
+			return
+		}
+	}
+	diags.AddError("Failed to generate DKIM key pair", err.Error())
+}
@@ -0,0 +1,212 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dmarcbuilder"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dnsresolver"
+)
+
+var _ datasource.DataSource = &DmarcDataSource{}
+var _ datasource.DataSourceWithConfigure = &DmarcDataSource{}
+
+func NewDmarcDataSource() datasource.DataSource {
+	return &DmarcDataSource{
+		resolver: dnsresolver.LiveResolver{},
+	}
+}
+
+type DmarcDataSource struct {
+	resolver dmarcbuilder.Resolver
+}
+
+type DmarcDataSourceModel struct {
+	Domain               types.String `tfsdk:"domain"`
+	OrganizationalDomain types.String `tfsdk:"organizational_domain"`
+	Found                types.Bool   `tfsdk:"found"`
+	RecordName           types.String `tfsdk:"record_name"`
+	Inherited            types.Bool   `tfsdk:"inherited"`
+	Value                types.String `tfsdk:"value"`
+	Version              types.String `tfsdk:"version"`
+	Policy               types.String `tfsdk:"policy"`
+	EffectivePolicy      types.String `tfsdk:"effective_policy"`
+	SubdomainPolicy      types.String `tfsdk:"subdomain_policy"`
+	NonexistentPolicy    types.String `tfsdk:"nonexistent_policy"`
+	AlignmentSPF         types.String `tfsdk:"alignment_spf"`
+	AlignmentDKIM        types.String `tfsdk:"alignment_dkim"`
+	Percent              types.Int32  `tfsdk:"percent"`
+	Testing              types.Bool   `tfsdk:"testing"`
+	RUA                  types.List   `tfsdk:"rua"`
+	RUF                  types.List   `tfsdk:"ruf"`
+	FailureOptions       types.String `tfsdk:"failure_options"`
+	FailureFormat        types.String `tfsdk:"failure_format"`
+	ReportInterval       types.Int32  `tfsdk:"report_interval"`
+}
+
+func (d *DmarcDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dmarc"
+}
+
+func (d *DmarcDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up the DMARC record that applies to a domain. The record at `_dmarc.<domain>` is used when present, otherwise the record of the organizational domain is used as described in RFC 7489. Parsed tags that are not published in the record are null.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain to look up the DMARC record for",
+				Required:            true,
+			},
+			"organizational_domain": schema.StringAttribute{
+				MarkdownDescription: "The organizational domain of `domain`, determined using the Public Suffix List",
+				Computed:            true,
+			},
+			"found": schema.BoolAttribute{
+				MarkdownDescription: "Whether a DMARC record applies to `domain`",
+				Computed:            true,
+			},
+			"record_name": schema.StringAttribute{
+				MarkdownDescription: "The name the DMARC record was found at, `_dmarc.<domain>` or `_dmarc.<organizational_domain>`",
+				Computed:            true,
+			},
+			"inherited": schema.BoolAttribute{
+				MarkdownDescription: "Whether the DMARC record was found at the organizational domain rather than at `domain`",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The raw DMARC record",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The DMARC version (v=)",
+				Computed:            true,
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "The DMARC policy (p=)",
+				Computed:            true,
+			},
+			"effective_policy": schema.StringAttribute{
+				MarkdownDescription: "The policy receivers apply to mail from `domain`: `subdomain_policy` when the record is inherited and sets it, `none` when the record has no valid policy but requests aggregate reports as described in RFC 7489 section 6.6.3, and `policy` otherwise",
+				Computed:            true,
+			},
+			"subdomain_policy": schema.StringAttribute{
+				MarkdownDescription: "The DMARC policy for subdomains (sp=)",
+				Computed:            true,
+			},
+			"nonexistent_policy": schema.StringAttribute{
+				MarkdownDescription: "The DMARC policy for non-existent subdomains (np=)",
+				Computed:            true,
+			},
+			"alignment_spf": schema.StringAttribute{
+				MarkdownDescription: "The SPF alignment mode (aspf=), 'r' or 's'",
+				Computed:            true,
+			},
+			"alignment_dkim": schema.StringAttribute{
+				MarkdownDescription: "The DKIM alignment mode (adkim=), 'r' or 's'",
+				Computed:            true,
+			},
+			"percent": schema.Int32Attribute{
+				MarkdownDescription: "Percentage of messages the policy is applied to (pct=)",
+				Computed:            true,
+			},
+			"testing": schema.BoolAttribute{
+				MarkdownDescription: "Whether the record is published in testing mode (t=y)",
+				Computed:            true,
+			},
+			"rua": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Aggregate report targets (rua=)",
+				Computed:            true,
+			},
+			"ruf": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Failure report targets (ruf=)",
+				Computed:            true,
+			},
+			"failure_options": schema.StringAttribute{
+				MarkdownDescription: "Failure reporting options (fo=)",
+				Computed:            true,
+			},
+			"failure_format": schema.StringAttribute{
+				MarkdownDescription: "Failure report format (rf=)",
+				Computed:            true,
+			},
+			"report_interval": schema.Int32Attribute{
+				MarkdownDescription: "Aggregate report interval in seconds (ri=)",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DmarcDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(dmarcbuilder.Resolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a DNS resolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *DmarcDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DmarcDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := dmarcbuilder.LookupRecord(data.Domain.ValueString(), d.resolver)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to look up DMARC record", err.Error())
+		return
+	}
+	if record.Discarded != "" {
+		resp.Diagnostics.AddWarning("DMARC record discarded", record.Discarded+", so no DMARC policy applies to "+record.Domain)
+	}
+
+	config := record.Config
+	data.OrganizationalDomain = types.StringValue(record.OrganizationalDomain)
+	data.Found = types.BoolValue(record.Name != "")
+	data.RecordName = stringOrNull(record.Name)
+	data.Inherited = types.BoolValue(record.Name != "" && record.Name != "_dmarc."+record.Domain)
+	data.Value = stringOrNull(record.Value)
+	data.Version = stringOrNull(config.Version)
+	data.Policy = stringOrNull(config.Policy)
+	data.EffectivePolicy = stringOrNull(record.Policy)
+	data.SubdomainPolicy = stringOrNull(config.SubdomainPolicy)
+	data.NonexistentPolicy = stringOrNull(config.NonexistentPolicy)
+	data.AlignmentSPF = stringOrNull(config.AlignmentSPF)
+	data.AlignmentDKIM = stringOrNull(config.AlignmentDKIM)
+	data.Percent = int32OrNull(config.Percent)
+	if config.PercentSet {
+		data.Percent = types.Int32Value(config.Percent)
+	}
+	data.Testing = types.BoolValue(config.Testing)
+	data.FailureOptions = stringOrNull(config.FailureOptions)
+	data.FailureFormat = stringOrNull(config.FailureFormat)
+	data.ReportInterval = int32OrNull(config.ReportInterval)
+
+	resp.Diagnostics.Append(listOrNull(ctx, config.RUA, &data.RUA)...)
+	resp.Diagnostics.Append(listOrNull(ctx, config.RUF, &data.RUF)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
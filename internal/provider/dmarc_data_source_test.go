@@ -0,0 +1,273 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/provider"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDmarcDataSource_Metadata(t *testing.T) {
+	d := provider.NewDmarcDataSource()
+	resp := datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "dnshelper"}, &resp)
+	require.Equal(t, "dnshelper_dmarc", resp.TypeName)
+}
+
+func TestDmarcDataSource_Schema(t *testing.T) {
+	d := provider.NewDmarcDataSource()
+	resp := datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(context.Background()).HasError())
+	require.True(t, resp.Schema.Attributes["domain"].IsRequired())
+}
+
+func TestDmarcDataSource_Read(t *testing.T) {
+	tests := []struct {
+		name        string
+		domain      string
+		want        provider.DmarcDataSourceModel
+		wantWarning string
+		wantErr     bool
+	}{
+		{
+			name:   "inherited from organizational domain",
+			domain: "www.example.com",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("www.example.com"),
+				OrganizationalDomain: types.StringValue("example.com"),
+				Found:                types.BoolValue(true),
+				RecordName:           types.StringValue("_dmarc.example.com"),
+				Inherited:            types.BoolValue(true),
+				Value:                types.StringValue("v=DMARC1; p=reject; sp=quarantine; rua=mailto:dmarc@example.com"),
+				Version:              types.StringValue("DMARC1"),
+				Policy:               types.StringValue("reject"),
+				EffectivePolicy:      types.StringValue("quarantine"),
+				SubdomainPolicy:      types.StringValue("quarantine"),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Null(),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListValueMust(types.StringType, sliceToValues([]string{"mailto:dmarc@example.com"})),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+		},
+		{
+			name:   "record at domain",
+			domain: "mail.example.org",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("mail.example.org"),
+				OrganizationalDomain: types.StringValue("example.org"),
+				Found:                types.BoolValue(true),
+				RecordName:           types.StringValue("_dmarc.mail.example.org"),
+				Inherited:            types.BoolValue(false),
+				Value:                types.StringValue("v=DMARC1; p=none"),
+				Version:              types.StringValue("DMARC1"),
+				Policy:               types.StringValue("none"),
+				EffectivePolicy:      types.StringValue("none"),
+				SubdomainPolicy:      types.StringNull(),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Null(),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListNull(types.StringType),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+		},
+		{
+			name:   "no record",
+			domain: "example.edu",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("example.edu"),
+				OrganizationalDomain: types.StringValue("example.edu"),
+				Found:                types.BoolValue(false),
+				RecordName:           types.StringNull(),
+				Inherited:            types.BoolValue(false),
+				Value:                types.StringNull(),
+				Version:              types.StringNull(),
+				Policy:               types.StringNull(),
+				EffectivePolicy:      types.StringNull(),
+				SubdomainPolicy:      types.StringNull(),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Null(),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListNull(types.StringType),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+		},
+		{
+			name:   "reports without policy",
+			domain: "reports.example.org",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("reports.example.org"),
+				OrganizationalDomain: types.StringValue("example.org"),
+				Found:                types.BoolValue(true),
+				RecordName:           types.StringValue("_dmarc.reports.example.org"),
+				Inherited:            types.BoolValue(false),
+				Value:                types.StringValue("v=DMARC1; rua=mailto:a@example.com"),
+				Version:              types.StringValue("DMARC1"),
+				Policy:               types.StringNull(),
+				EffectivePolicy:      types.StringValue("none"),
+				SubdomainPolicy:      types.StringNull(),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Null(),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListValueMust(types.StringType, sliceToValues([]string{"mailto:a@example.com"})),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+		},
+		{
+			name:   "zero percent",
+			domain: "pilot.example.org",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("pilot.example.org"),
+				OrganizationalDomain: types.StringValue("example.org"),
+				Found:                types.BoolValue(true),
+				RecordName:           types.StringValue("_dmarc.pilot.example.org"),
+				Inherited:            types.BoolValue(false),
+				Value:                types.StringValue("v=DMARC1; p=reject; pct=0"),
+				Version:              types.StringValue("DMARC1"),
+				Policy:               types.StringValue("reject"),
+				EffectivePolicy:      types.StringValue("reject"),
+				SubdomainPolicy:      types.StringNull(),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Value(0),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListNull(types.StringType),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+		},
+		{
+			name:   "multiple records",
+			domain: "example.net",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("example.net"),
+				OrganizationalDomain: types.StringValue("example.net"),
+				Found:                types.BoolValue(false),
+				RecordName:           types.StringNull(),
+				Inherited:            types.BoolValue(false),
+				Value:                types.StringNull(),
+				Version:              types.StringNull(),
+				Policy:               types.StringNull(),
+				EffectivePolicy:      types.StringNull(),
+				SubdomainPolicy:      types.StringNull(),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Null(),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListNull(types.StringType),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+			wantWarning: "_dmarc.example.net publishes more than one DMARC record, so no DMARC policy applies to example.net",
+		},
+		{
+			name:   "invalid policy does not fall back",
+			domain: "nopolicy.example.com",
+			want: provider.DmarcDataSourceModel{
+				Domain:               types.StringValue("nopolicy.example.com"),
+				OrganizationalDomain: types.StringValue("example.com"),
+				Found:                types.BoolValue(false),
+				RecordName:           types.StringNull(),
+				Inherited:            types.BoolValue(false),
+				Value:                types.StringNull(),
+				Version:              types.StringNull(),
+				Policy:               types.StringNull(),
+				EffectivePolicy:      types.StringNull(),
+				SubdomainPolicy:      types.StringNull(),
+				NonexistentPolicy:    types.StringNull(),
+				AlignmentSPF:         types.StringNull(),
+				AlignmentDKIM:        types.StringNull(),
+				Percent:              types.Int32Null(),
+				Testing:              types.BoolValue(false),
+				RUA:                  types.ListNull(types.StringType),
+				RUF:                  types.ListNull(types.StringType),
+				FailureOptions:       types.StringNull(),
+				FailureFormat:        types.StringNull(),
+				ReportInterval:       types.Int32Null(),
+			},
+			wantWarning: "the DMARC record at _dmarc.nopolicy.example.com has no valid policy and no valid aggregate report URI, so no DMARC policy applies to nopolicy.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			d := provider.NewDmarcDataSource()
+
+			schemaResp := datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			configureResp := datasource.ConfigureResponse{}
+			d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{
+				ProviderData: testutil.NewMockDNSResolver(),
+			}, &configureResp)
+			require.False(t, configureResp.Diagnostics.HasError())
+
+			req := datasource.ReadRequest{
+				Config: configWithAttributes(ctx, schemaResp.Schema, map[string]tftypes.Value{
+					"domain": tftypes.NewValue(tftypes.String, tt.domain),
+				}),
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			d.Read(ctx, req, &resp)
+
+			if tt.wantErr {
+				require.True(t, resp.Diagnostics.HasError())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if tt.wantWarning == "" {
+				require.Empty(t, resp.Diagnostics.Warnings())
+			} else {
+				require.Len(t, resp.Diagnostics.Warnings(), 1)
+				require.Equal(t, tt.wantWarning, resp.Diagnostics.Warnings()[0].Detail())
+			}
+
+			var got provider.DmarcDataSourceModel
+			require.False(t, resp.State.Get(ctx, &got).HasError())
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// configWithAttributes returns a data source config with the given attribute
+// values, leaving every other attribute null.
+func configWithAttributes(ctx context.Context, s schema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	return tfsdk.Config{
+		Schema: s,
+		Raw:    objectWithAttributes(s.Type().TerraformType(ctx).(tftypes.Object), values),
+	}
+}
+
+// ephemeralConfigWithAttributes is configWithAttributes for ephemeral
+// resources.
+func ephemeralConfigWithAttributes(ctx context.Context, s ephemeralschema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	return tfsdk.Config{
+		Schema: s,
+		Raw:    objectWithAttributes(s.Type().TerraformType(ctx).(tftypes.Object), values),
+	}
+}
+
+func objectWithAttributes(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	return tftypes.NewValue(objectType, attrs)
+}
+
+func sliceToValues(slice []string) []attr.Value {
+	values := make([]attr.Value, len(slice))
+	for i, s := range slice {
+		values[i] = types.StringValue(s)
+	}
+	return values
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/marceloalmeida/terraform-provider-dnshelper/dnshelper/dnsresolver"
 
 	tffunction "github.com/marceloalmeida/terraform-provider-dnshelper/internal/function"
 )
@@ -46,7 +47,7 @@ func (p *DnshelperProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	client := http.DefaultClient
-	resp.DataSourceData = client
+	resp.DataSourceData = dnsresolver.LiveResolver{}
 	resp.ResourceData = client
 }
 
@@ -55,11 +56,15 @@ func (p *DnshelperProvider) Resources(ctx context.Context) []func() resource.Res
 }
 
 func (p *DnshelperProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewDKIMKeypairEphemeralResource,
+	}
 }
 
 func (p *DnshelperProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewDmarcDataSource,
+	}
 }
 
 func (p *DnshelperProvider) Functions(ctx context.Context) []func() function.Function {
@@ -67,6 +72,23 @@ func (p *DnshelperProvider) Functions(ctx context.Context) []func() function.Fun
 		tffunction.NewSPFBuilderFunction,
 		tffunction.NewCAABuilderFunction,
 		tffunction.NewDmarcBuilderFunction,
+		tffunction.NewCAABuilderObjectFunction,
+		tffunction.NewCAABuilderRecordsFunction,
+		tffunction.NewDmarcBuilderObjectFunction,
+		tffunction.NewDmarcEffectivePolicyFunction,
+		tffunction.NewDmarcRolloutFunction,
+		tffunction.NewDmarcRolloutScheduleFunction,
+		tffunction.NewCAAParseFunction,
+		tffunction.NewCAACheckFunction,
+		tffunction.NewDKIMBuilderFunction,
+		tffunction.NewDKIMParseFunction,
+		tffunction.NewDKIMRotationFunction,
+		tffunction.NewMTASTSBuilderFunction,
+		tffunction.NewMTASTSMXCheckFunction,
+		tffunction.NewTLSRPTBuilderFunction,
+		tffunction.NewBIMIBuilderFunction,
+		tffunction.NewTLSABuilderFunction,
+		tffunction.NewSSHFPBuilderFunction,
 	}
 }
 
@@ -0,0 +1,38 @@
+// Copyright Marcelo Almeida 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringOrNull returns a null value for empty strings, so attributes for
+// record tags that are not published read as null rather than "".
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func int32OrNull(value int32) types.Int32 {
+	if value == 0 {
+		return types.Int32Null()
+	}
+	return types.Int32Value(value)
+}
+
+func listOrNull(ctx context.Context, values []string, target *types.List) diag.Diagnostics {
+	if len(values) == 0 {
+		*target = types.ListNull(types.StringType)
+		return nil
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, values)
+	*target = list
+	return diags
+}
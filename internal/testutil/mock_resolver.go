@@ -4,13 +4,22 @@
 package testutil
 
 import (
+	"encoding/json"
 	"log"
+	"os"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/spflib"
 )
 
+// testdataDNS is relative to the test packages, which all live two levels
+// below the repository root.
+const testdataDNS = "../../internal/testutil/testdata-dns.json"
+
 type MockResolver struct {
-	TxtRecords map[string][]string
+	TxtRecords   map[string][]string
+	CAARecords   map[string][]string
+	CNAMERecords map[string]string
+	MXRecords    map[string][]string
 }
 
 func (m *MockResolver) GetTXT(domain string) ([]string, error) {
@@ -20,6 +29,18 @@ func (m *MockResolver) GetTXT(domain string) ([]string, error) {
 	return nil, nil
 }
 
+func (m *MockResolver) GetCAA(name string) ([]string, error) {
+	return m.CAARecords[name], nil
+}
+
+func (m *MockResolver) GetCNAME(name string) (string, error) {
+	return m.CNAMERecords[name], nil
+}
+
+func (m *MockResolver) GetMX(name string) ([]string, error) {
+	return m.MXRecords[name], nil
+}
+
 func (m *MockResolver) GetSPF(domain string) (string, error) {
 	if records, ok := m.TxtRecords[domain]; ok && len(records) > 0 {
 		return records[0], nil
@@ -28,10 +49,55 @@ func (m *MockResolver) GetSPF(domain string) (string, error) {
 }
 
 func NewMockResolver() spflib.Resolver {
-	res, err := spflib.NewCache("../../internal/testutil/testdata-dns.json")
+	res, err := spflib.NewCache(testdataDNS)
 	if err != nil {
 		log.Fatalf("error creating mock resolver: %v", err)
 		return nil
 	}
 	return res
 }
+
+// NewMockDNSResolver returns a MockResolver serving testdata-dns.json, with
+// SPF entries served as TXT records.
+func NewMockDNSResolver() *MockResolver {
+	data, err := os.ReadFile(testdataDNS)
+	if err != nil {
+		log.Fatalf("error reading mock resolver data: %v", err)
+		return nil
+	}
+
+	var entries map[string]struct {
+		SPF   string
+		TXT   []string
+		CAA   []string
+		CNAME string
+		MX    []string
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Fatalf("error parsing mock resolver data: %v", err)
+		return nil
+	}
+
+	m := &MockResolver{
+		TxtRecords:   map[string][]string{},
+		CAARecords:   map[string][]string{},
+		CNAMERecords: map[string]string{},
+		MXRecords:    map[string][]string{},
+	}
+	for name, entry := range entries {
+		if len(entry.CAA) > 0 {
+			m.CAARecords[name] = entry.CAA
+		}
+		if len(entry.MX) > 0 {
+			m.MXRecords[name] = entry.MX
+		}
+		if entry.CNAME != "" {
+			m.CNAMERecords[name] = entry.CNAME
+		}
+		if entry.SPF != "" {
+			m.TxtRecords[name] = append(m.TxtRecords[name], entry.SPF)
+		}
+		m.TxtRecords[name] = append(m.TxtRecords[name], entry.TXT...)
+	}
+	return m
+}